@@ -0,0 +1,99 @@
+package strainapiclient
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AdaptiveTimeoutConfig configures per-endpoint timeouts that follow the
+// latency observed for each endpoint: the timeout is the Percentile of the
+// most recent SampleSize latencies times Multiplier, bounded by Min and Max.
+// Until an endpoint has MinSamples latencies, Max is used.
+type AdaptiveTimeoutConfig struct {
+	Percentile float64
+	Multiplier float64
+	Min        time.Duration
+	Max        time.Duration
+	SampleSize int
+	MinSamples int
+}
+
+// DefaultAdaptiveTimeoutConfig is p99 x 2 of the last 100 requests per
+// endpoint, between 1 and 30 seconds, once 20 requests have been seen.
+var DefaultAdaptiveTimeoutConfig = AdaptiveTimeoutConfig{
+	Percentile: 0.99,
+	Multiplier: 2,
+	Min:        time.Second,
+	Max:        30 * time.Second,
+	SampleSize: 100,
+	MinSamples: 20,
+}
+
+// adaptiveTimeouts tracks recent latencies per endpoint.
+type adaptiveTimeouts struct {
+	config AdaptiveTimeoutConfig
+
+	mutex     sync.Mutex
+	latencies map[string][]time.Duration
+}
+
+func newAdaptiveTimeouts(config AdaptiveTimeoutConfig) *adaptiveTimeouts {
+	return &adaptiveTimeouts{config: config, latencies: make(map[string][]time.Duration)}
+}
+
+// timeout returns the current timeout for endpoint.
+func (a *adaptiveTimeouts) timeout(endpoint string) time.Duration {
+	a.mutex.Lock()
+	samples := append(make([]time.Duration, 0), a.latencies[endpoint]...)
+	a.mutex.Unlock()
+
+	if len(samples) == 0 || len(samples) < a.config.MinSamples {
+		return a.config.Max
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	index := int(a.config.Percentile*float64(len(samples))+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(samples) {
+		index = len(samples) - 1
+	}
+
+	timeout := time.Duration(float64(samples[index]) * a.config.Multiplier)
+	if timeout < a.config.Min {
+		timeout = a.config.Min
+	}
+	if a.config.Max > 0 && timeout > a.config.Max {
+		timeout = a.config.Max
+	}
+
+	return timeout
+}
+
+// observe records the latency of a request to endpoint, keeping only the
+// most recent SampleSize latencies.
+func (a *adaptiveTimeouts) observe(endpoint string, latency time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	samples := append(a.latencies[endpoint], latency)
+	if a.config.SampleSize > 0 && len(samples) > a.config.SampleSize {
+		samples = samples[len(samples)-a.config.SampleSize:]
+	}
+	a.latencies[endpoint] = samples
+}
+
+// adaptiveTimeoutEndpoint returns the endpoint a resource path (the part
+// after the API Key) belongs to, dropping search terms and strain IDs so
+// "/strains/data/desc/1" and "/strains/data/desc/2" share latencies.
+func adaptiveTimeoutEndpoint(resourcePath string) string {
+	segments := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	if len(segments) == 4 && segments[0] == "strains" {
+		segments = segments[:3]
+	}
+
+	return strings.Join(segments, "/")
+}
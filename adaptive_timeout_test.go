@@ -0,0 +1,77 @@
+package strainapiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	timeouts := newAdaptiveTimeouts(AdaptiveTimeoutConfig{
+		Percentile: 0.9, Multiplier: 2, Min: 10 * time.Millisecond, Max: time.Second, SampleSize: 10, MinSamples: 5,
+	})
+
+	if timeout := timeouts.timeout("searchdata/effects"); timeout != time.Second {
+		t.Errorf("Expected Max before MinSamples but got %s", timeout)
+	}
+
+	for i := 1; i <= 20; i++ {
+		timeouts.observe("searchdata/effects", time.Duration(i)*time.Millisecond)
+	}
+
+	// The last 10 samples are 11ms-20ms, so p90 is 19ms
+	if timeout := timeouts.timeout("searchdata/effects"); timeout != 38*time.Millisecond {
+		t.Errorf("Expected p90 x 2 of 38ms but got %s", timeout)
+	}
+
+	timeouts.observe("searchdata/flavors", time.Microsecond)
+	for i := 0; i < 5; i++ {
+		timeouts.observe("searchdata/flavors", time.Microsecond)
+	}
+	if timeout := timeouts.timeout("searchdata/flavors"); timeout != 10*time.Millisecond {
+		t.Errorf("Expected the timeout to be bounded by Min but got %s", timeout)
+	}
+}
+
+func TestAdaptiveTimeoutEndpoint(t *testing.T) {
+	expected := map[string]string{
+		"/searchdata/effects":      "searchdata/effects",
+		"/strains/search/all":      "strains/search/all",
+		"/strains/search/name/Af":  "strains/search/name",
+		"/strains/data/effects/12": "strains/data/effects",
+	}
+
+	for path, endpoint := range expected {
+		if actual := adaptiveTimeoutEndpoint(path); actual != endpoint {
+			t.Errorf("Expected endpoint %s for %s but got %s", endpoint, path, actual)
+		}
+	}
+}
+
+func TestWithAdaptiveTimeout(t *testing.T) {
+	var slow int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&slow) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("[\"Earthy\"]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy), WithAdaptiveTimeout(AdaptiveTimeoutConfig{
+		Percentile: 0.99, Multiplier: 2, Min: 20 * time.Millisecond, Max: time.Second, SampleSize: 10, MinSamples: 5,
+	}))
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.ListAllFlavors(); err != nil {
+			t.Errorf("Expected no error while learning latencies but got: %s", err)
+		}
+	}
+
+	atomic.StoreInt32(&slow, 1)
+	if _, err := client.ListAllFlavors(); err == nil {
+		t.Error("Expected a request far slower than observed latency to time out")
+	}
+}
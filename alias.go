@@ -0,0 +1,87 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// builtInStrainAliases maps common street aliases to the strain names
+// used by the API.  Aliases that are words or parts of other strain names,
+// like "Cookies" or "AK", aren't included, since resolving them would turn
+// a substring search into a search for one strain.
+var builtInStrainAliases = map[string]string{
+	"GSC":  "Girl Scout Cookies",
+	"GDP":  "Granddaddy Purple",
+	"GG4":  "Gorilla Glue #4",
+	"GG#4": "Gorilla Glue #4",
+	"NL":   "Northern Lights",
+	"WW":   "White Widow",
+	"BD":   "Blue Dream",
+	"JH":   "Jack Herer",
+	"SLH":  "Super Lemon Haze",
+	"SSH":  "Super Silver Haze",
+	"GC":   "Green Crack",
+}
+
+// AliasRegistry resolves strain aliases to the names the API knows them
+// by.  Aliases are matched case-insensitively.  It is safe for concurrent use.
+type AliasRegistry struct {
+	mutex   sync.RWMutex
+	aliases map[string]string
+}
+
+// NewAliasRegistry creates a new AliasRegistry with no aliases.
+func NewAliasRegistry() *AliasRegistry {
+	return &AliasRegistry{aliases: make(map[string]string)}
+}
+
+// DefaultAliasRegistry creates a new AliasRegistry seeded with a built-in
+// list of common street aliases.
+func DefaultAliasRegistry() *AliasRegistry {
+	registry := NewAliasRegistry()
+	registry.RegisterAll(builtInStrainAliases)
+	return registry
+}
+
+// Register adds (or replaces) alias as another name for the strain name.
+func (a *AliasRegistry) Register(alias string, name string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.aliases[strings.ToLower(strings.TrimSpace(alias))] = name
+}
+
+// RegisterAll adds every alias in aliases, which maps aliases to strain names.
+func (a *AliasRegistry) RegisterAll(aliases map[string]string) {
+	for alias, name := range aliases {
+		a.Register(alias, name)
+	}
+}
+
+// Load adds the aliases in r, a JSON object mapping aliases to strain names.
+func (a *AliasRegistry) Load(r io.Reader) error {
+	aliases := make(map[string]string)
+
+	if err := json.NewDecoder(r).Decode(&aliases); err != nil {
+		return fmt.Errorf("Problem parsing aliases: %w", err)
+	}
+
+	a.RegisterAll(aliases)
+	return nil
+}
+
+// Resolve returns the strain name for name if it is a registered alias,
+// otherwise name itself.
+func (a *AliasRegistry) Resolve(name string) string {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if resolved, found := a.aliases[strings.ToLower(strings.TrimSpace(name))]; found {
+		return resolved
+	}
+
+	return name
+}
@@ -0,0 +1,54 @@
+package strainapiclient
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAliasRegistry(t *testing.T) {
+	registry := DefaultAliasRegistry()
+
+	if resolved := registry.Resolve("gsc"); resolved != "Girl Scout Cookies" {
+		t.Errorf("Expected 'gsc' to resolve to 'Girl Scout Cookies' but got '%s'", resolved)
+	}
+
+	if resolved := registry.Resolve("Afpak"); resolved != "Afpak" {
+		t.Errorf("Expected a non-alias to resolve to itself but got '%s'", resolved)
+	}
+
+	if err := registry.Load(strings.NewReader(`{"Afghan Pakistani": "Afpak"}`)); err != nil {
+		t.Errorf("Expected no error loading aliases but got: %s", err)
+	}
+
+	if resolved := registry.Resolve("AFGHAN PAKISTANI"); resolved != "Afpak" {
+		t.Errorf("Expected a loaded alias to resolve to 'Afpak' but got '%s'", resolved)
+	}
+}
+
+func TestSearchStrainsByNameResolvesAliases(t *testing.T) {
+	client := NewClient("test-key", WithAliasRegistry(DefaultAliasRegistry()))
+
+	actualPath := ""
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		actualPath = path
+		return []byte("[]"), nil
+	})
+
+	client.SearchStrainsByName("GSC")
+
+	if !strings.HasSuffix(actualPath, "/strains/search/name/Girl%20Scout%20Cookies") {
+		t.Errorf("Expected the alias to be resolved in the search path but got '%s'", actualPath)
+	}
+
+	client.SearchStrainsByName("GG4")
+
+	if !strings.HasSuffix(actualPath, "/strains/search/name/Gorilla%20Glue%20%234") {
+		t.Errorf("Expected the resolved name to be escaped in the search path but got '%s'", actualPath)
+	}
+
+	client.SearchStrainsByName("Cookies")
+
+	if !strings.HasSuffix(actualPath, "/strains/search/name/Cookies") {
+		t.Errorf("Expected a generic word to be searched as is but got '%s'", actualPath)
+	}
+}
@@ -0,0 +1,157 @@
+package strainapiclient
+
+import (
+	"sort"
+)
+
+// Scorer assigns a score to a Strain; higher scores are seeded higher.
+type Scorer func(strain Strain) float64
+
+// ScoreByPositiveEffects is a Scorer that scores a strain by the number
+// of positive effects it has.
+func ScoreByPositiveEffects(strain Strain) float64 {
+	return float64(len(strain.Effects[EffectTypePositive]))
+}
+
+// Matchup is a head-to-head pairing of two strains in the first round of a
+// bracket, along with how their effects and flavors differ.  When there are
+// not enough strains to fill the bracket, the top seeds get a bye: Bye is
+// true and B is the zero Strain.
+type Matchup struct {
+	SeedA int
+	SeedB int
+	A     Strain
+	B     Strain
+	Bye   bool
+
+	SharedEffects []string
+	OnlyAEffects  []string
+	OnlyBEffects  []string
+	SharedFlavors []Flavor
+	OnlyAFlavors  []Flavor
+	OnlyBFlavors  []Flavor
+}
+
+// BuildBracket seeds strains by seedBy (ties broken by name) and returns
+// the first-round Matchups of a single-elimination bracket in bracket
+// order, so the winners of adjacent Matchups meet in the next round.
+// Seed 1 plays the lowest seed, seed 2 the second lowest, and so on.
+func BuildBracket(strains []Strain, seedBy Scorer) []Matchup {
+	matchups := make([]Matchup, 0)
+	if len(strains) < 2 {
+		return matchups
+	}
+
+	seeded := make([]Strain, len(strains))
+	copy(seeded, strains)
+
+	scores := make(map[string]float64)
+	for _, strain := range seeded {
+		scores[strain.Name] = seedBy(strain)
+	}
+
+	sort.SliceStable(seeded, func(i, j int) bool {
+		if scores[seeded[i].Name] != scores[seeded[j].Name] {
+			return scores[seeded[i].Name] > scores[seeded[j].Name]
+		}
+		return seeded[i].Name < seeded[j].Name
+	})
+
+	size := 2
+	for size < len(seeded) {
+		size *= 2
+	}
+
+	order := bracketSeedOrder(size)
+	for index := 0; index < len(order); index += 2 {
+		seedA, seedB := order[index], order[index+1]
+
+		matchup := Matchup{SeedA: seedA, SeedB: seedB, A: seeded[seedA-1]}
+		if seedB > len(seeded) {
+			matchup.Bye = true
+		} else {
+			matchup.B = seeded[seedB-1]
+			matchup.SharedEffects, matchup.OnlyAEffects, matchup.OnlyBEffects = diffStrings(allEffectNames(matchup.A), allEffectNames(matchup.B))
+			matchup.SharedFlavors, matchup.OnlyAFlavors, matchup.OnlyBFlavors = diffFlavors(matchup.A.Flavors, matchup.B.Flavors)
+		}
+
+		matchups = append(matchups, matchup)
+	}
+
+	return matchups
+}
+
+// bracketSeedOrder returns the seeds 1 through size in standard bracket
+// order, e.g. [1 8 4 5 2 7 3 6] for a size of 8.
+func bracketSeedOrder(size int) []int {
+	order := []int{1, 2}
+
+	for length := 4; length <= size; length *= 2 {
+		next := make([]int, 0)
+		for _, seed := range order {
+			next = append(next, seed, length+1-seed)
+		}
+		order = next
+	}
+
+	return order
+}
+
+func allEffectNames(strain Strain) []string {
+	names := make([]string, 0)
+	for _, effectType := range []EffectType{EffectTypePositive, EffectTypeNegative, EffectTypeMedical} {
+		names = append(names, strain.Effects[effectType]...)
+	}
+
+	return names
+}
+
+// diffStrings returns the values found in both a and b, only in a, and only in b.
+func diffStrings(a []string, b []string) (shared []string, onlyA []string, onlyB []string) {
+	shared, onlyA, onlyB = make([]string, 0), make([]string, 0), make([]string, 0)
+
+	inB := make(map[string]bool)
+	for _, value := range b {
+		inB[value] = true
+	}
+
+	inA := make(map[string]bool)
+	for _, value := range a {
+		inA[value] = true
+		if inB[value] {
+			shared = append(shared, value)
+		} else {
+			onlyA = append(onlyA, value)
+		}
+	}
+
+	for _, value := range b {
+		if !inA[value] {
+			onlyB = append(onlyB, value)
+		}
+	}
+
+	return shared, onlyA, onlyB
+}
+
+func diffFlavors(a []Flavor, b []Flavor) (shared []Flavor, onlyA []Flavor, onlyB []Flavor) {
+	toStrings := func(flavors []Flavor) []string {
+		values := make([]string, len(flavors))
+		for index, flavor := range flavors {
+			values[index] = string(flavor)
+		}
+		return values
+	}
+
+	toFlavors := func(values []string) []Flavor {
+		flavors := make([]Flavor, len(values))
+		for index, value := range values {
+			flavors[index] = Flavor(value)
+		}
+		return flavors
+	}
+
+	sharedStrings, onlyAStrings, onlyBStrings := diffStrings(toStrings(a), toStrings(b))
+
+	return toFlavors(sharedStrings), toFlavors(onlyAStrings), toFlavors(onlyBStrings)
+}
@@ -0,0 +1,61 @@
+package strainapiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildBracketSeedsAndByes(t *testing.T) {
+	strains := make([]Strain, 0)
+	for _, name := range []string{"A", "B", "C", "D", "E", "F"} {
+		strains = append(strains, Strain{Name: name})
+	}
+
+	// Score in reverse alphabetical order so "F" is the first seed.
+	scoreByName := func(strain Strain) float64 { return float64(strain.Name[0]) }
+
+	matchups := BuildBracket(strains, scoreByName)
+
+	expectedSeeds := [][2]int{{1, 8}, {4, 5}, {2, 7}, {3, 6}}
+	if len(matchups) != len(expectedSeeds) {
+		t.Errorf("Expected %d matchups but got %d", len(expectedSeeds), len(matchups))
+		return
+	}
+
+	for index, expected := range expectedSeeds {
+		matchup := matchups[index]
+		if matchup.SeedA != expected[0] || matchup.SeedB != expected[1] {
+			t.Errorf("Expected matchup %d to be seeds %v but got %d vs %d", index, expected, matchup.SeedA, matchup.SeedB)
+		}
+	}
+
+	if !matchups[0].Bye || matchups[0].A.Name != "F" || !matchups[2].Bye || matchups[1].Bye {
+		t.Errorf("Expected the top two seeds to get byes; got %v", matchups)
+	}
+}
+
+func TestBuildBracketDiffs(t *testing.T) {
+	a := Strain{Name: "A", Flavors: []Flavor{"Earthy", "Pine"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed", "Happy"}}}
+	b := Strain{Name: "B", Flavors: []Flavor{"Pine", "Sweet"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}, EffectTypeNegative: {"Dizzy"}}}
+
+	matchups := BuildBracket([]Strain{a, b}, ScoreByPositiveEffects)
+	if len(matchups) != 1 {
+		t.Errorf("Expected 1 matchup but got %d", len(matchups))
+		return
+	}
+
+	matchup := matchups[0]
+	if !reflect.DeepEqual(matchup.SharedEffects, []string{"Happy"}) ||
+		!reflect.DeepEqual(matchup.OnlyAEffects, []string{"Relaxed"}) ||
+		!reflect.DeepEqual(matchup.OnlyBEffects, []string{"Dizzy"}) {
+		t.Errorf("Unexpected effect diff: %v / %v / %v", matchup.SharedEffects, matchup.OnlyAEffects, matchup.OnlyBEffects)
+	}
+
+	if !reflect.DeepEqual(matchup.SharedFlavors, []Flavor{"Pine"}) ||
+		!reflect.DeepEqual(matchup.OnlyAFlavors, []Flavor{"Earthy"}) ||
+		!reflect.DeepEqual(matchup.OnlyBFlavors, []Flavor{"Sweet"}) {
+		t.Errorf("Unexpected flavor diff: %v / %v / %v", matchup.SharedFlavors, matchup.OnlyAFlavors, matchup.OnlyBFlavors)
+	}
+}
@@ -0,0 +1,85 @@
+package strainapiclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is the storage used by a CachingClient.  Values are the JSON
+// encoding of results, so any byte store can back it: implement Cache
+// over Redis, Memcached, or your own store and pass it to
+// NewCachingClientWithCache.
+//
+// The CachingClient treats caching as best-effort: a Get error is treated
+// as a miss and Set errors are ignored.
+type Cache interface {
+	// Get returns the value for key and whether it was found (and not expired).
+	Get(key string) (value []byte, found bool, err error)
+	// Set stores value for key until ttl has passed.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes key.
+	Delete(key string) error
+	// Flush removes every key.
+	Flush() error
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-memory Cache safe for concurrent use.
+type MemoryCache struct {
+	mutex   sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache creates a new, empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entry, found := m.entries[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	if !time.Now().Before(entry.expiresAt) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.entries[key] = memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+// Flush implements Cache.
+func (m *MemoryCache) Flush() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.entries = make(map[string]memoryCacheEntry)
+	return nil
+}
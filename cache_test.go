@@ -0,0 +1,52 @@
+package strainapiclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	var cache Cache = NewMemoryCache()
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Millisecond)
+	cache.Set("c", []byte("3"), time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if value, found, err := cache.Get("a"); !found || err != nil || string(value) != "1" {
+		t.Errorf("Expected to find 'a' = 1 but got %s (found: %t, error: %v)", value, found, err)
+	}
+
+	if _, found, _ := cache.Get("b"); found {
+		t.Error("Expected 'b' to have expired")
+	}
+
+	cache.Delete("a")
+	if _, found, _ := cache.Get("a"); found {
+		t.Error("Expected 'a' to have been deleted")
+	}
+
+	cache.Flush()
+	if _, found, _ := cache.Get("c"); found {
+		t.Error("Expected 'c' to have been flushed")
+	}
+}
+
+func TestCachingClientWithCustomCache(t *testing.T) {
+	calls := make(map[string]int)
+	cache := NewMemoryCache()
+	client := NewCachingClientWithCache(newCountingTestClient(calls), cache, nil)
+
+	client.ListAllFlavors()
+
+	if _, found, _ := cache.Get("flavors"); !found {
+		t.Error("Expected the flavors to be stored in the custom cache")
+	}
+
+	client.Flush()
+	client.ListAllFlavors()
+
+	if calls["/searchdata/flavors"] != 2 {
+		t.Errorf("Expected 2 calls after flushing but got %d", calls["/searchdata/flavors"])
+	}
+}
@@ -0,0 +1,242 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CacheEndpoint identifies a group of Client calls that share a TTL
+// in a CachingClient.
+type CacheEndpoint string
+
+// The valid values of CacheEndpoint
+const (
+	// CacheEndpointEffects covers ListAllEffects
+	CacheEndpointEffects CacheEndpoint = "effects"
+	// CacheEndpointFlavors covers ListAllFlavors
+	CacheEndpointFlavors = "flavors"
+	// CacheEndpointStrains covers ListAllStrains
+	CacheEndpointStrains = "strains"
+	// CacheEndpointSearch covers the SearchStrainsBy* calls
+	CacheEndpointSearch = "search"
+	// CacheEndpointStrainData covers the GetStrain*ByStrainID calls
+	CacheEndpointStrainData = "strain-data"
+)
+
+// CacheTTLs is how long results are cached for each CacheEndpoint.
+// Endpoints with no TTL (or a TTL of zero) are not cached.
+type CacheTTLs map[CacheEndpoint]time.Duration
+
+// DefaultCacheTTLs returns the CacheTTLs used when none are passed to
+// NewCachingClient.  The data changes rarely, so TTLs are long.
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		CacheEndpointEffects:    24 * time.Hour,
+		CacheEndpointFlavors:    24 * time.Hour,
+		CacheEndpointStrains:    6 * time.Hour,
+		CacheEndpointSearch:     time.Hour,
+		CacheEndpointStrainData: 6 * time.Hour,
+	}
+}
+
+// CachingClient is a Client that wraps another Client and caches the
+// results of successful calls in a Cache for a per-endpoint TTL.  Empty
+// results and ErrNotFound errors are cached for the (usually shorter)
+// negative TTL of their endpoint; see SetNegativeCacheTTLs.  Other errors
+// are never cached.
+type CachingClient struct {
+	client Client
+	cache  Cache
+	ttls   CacheTTLs
+
+	negativeMutex sync.Mutex
+	negativeTTLs  CacheTTLs
+	negativeKeys  map[string]bool
+
+	inflightMutex sync.Mutex
+	inflight      map[string]*inflightFetch
+
+	decisions decisionHooks
+
+	prefetchMutex sync.Mutex
+	prefetchQueue []int
+	prefetching   bool
+}
+
+// NewCachingClient creates a new CachingClient wrapping client that caches
+// in a new MemoryCache.  If ttls is nil, DefaultCacheTTLs() is used.
+func NewCachingClient(client Client, ttls CacheTTLs) *CachingClient {
+	return NewCachingClientWithCache(client, NewMemoryCache(), ttls)
+}
+
+// NewCachingClientWithCache creates a new CachingClient wrapping client that
+// caches in cache.  If ttls is nil, DefaultCacheTTLs() is used.
+func NewCachingClientWithCache(client Client, cache Cache, ttls CacheTTLs) *CachingClient {
+	if ttls == nil {
+		ttls = DefaultCacheTTLs()
+	}
+
+	return &CachingClient{client: client, cache: cache, ttls: ttls, negativeTTLs: DefaultNegativeCacheTTLs(), negativeKeys: make(map[string]bool), inflight: make(map[string]*inflightFetch)}
+}
+
+// Flush removes every cached result.
+func (c *CachingClient) Flush() error {
+	return c.cache.Flush()
+}
+
+// cached unmarshals the cached value for key into value, calling fetch
+// and caching its result (as JSON) when there is no fresh cached value.
+// Empty results and ErrNotFound errors are cached for the endpoint's
+// negative TTL instead.  Concurrent misses for the same key share one
+// fetch.
+func (c *CachingClient) cached(endpoint CacheEndpoint, key string, value interface{}, fetch func() (interface{}, error)) error {
+	ttl := c.ttls[endpoint]
+	negativeTTL := c.negativeTTL(endpoint)
+
+	if ttl > 0 || negativeTTL > 0 {
+		if cachedJSON, found, err := c.cache.Get(key); err == nil && found {
+			c.decisions.emit(DecisionCacheHit, "CachingClient", key, "")
+			return json.Unmarshal(cachedJSON, value)
+		}
+	}
+
+	if negativeTTL > 0 {
+		if _, found, err := c.cache.Get(notFoundCacheKey(key)); err == nil && found {
+			c.decisions.emit(DecisionNegativeCacheHit, "CachingClient", key, "")
+			return fmt.Errorf("Cached not found result for %s: %w", key, ErrNotFound)
+		}
+	}
+
+	reason := "not cached or expired"
+	if ttl <= 0 {
+		reason = fmt.Sprintf("%s results are not cached", endpoint)
+	}
+
+	resultJSON, err := c.fetchOnce(key, reason, func() ([]byte, error) {
+		result, err := fetch()
+		if err != nil {
+			if negativeTTL > 0 && errors.Is(err, ErrNotFound) {
+				c.setNegative(notFoundCacheKey(key), []byte("null"), negativeTTL)
+			}
+			return nil, err
+		}
+
+		resultJSON, marshallErr := json.Marshal(result)
+		if marshallErr != nil {
+			return nil, fmt.Errorf("Problem caching result for %s: %w", key, marshallErr)
+		}
+
+		if negativeTTL > 0 && isEmptyResultJSON(resultJSON) {
+			c.setNegative(key, resultJSON, negativeTTL)
+		} else if ttl > 0 {
+			c.cache.Set(key, resultJSON, ttl)
+		}
+
+		return resultJSON, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resultJSON, value)
+}
+
+// ListAllEffects implements Client.
+func (c *CachingClient) ListAllEffects() ([]Effect, error) {
+	effects := make([]Effect, 0)
+	err := c.cached(CacheEndpointEffects, "effects", &effects, func() (interface{}, error) {
+		return c.client.ListAllEffects()
+	})
+	return effects, err
+}
+
+// ListAllFlavors implements Client.
+func (c *CachingClient) ListAllFlavors() ([]Flavor, error) {
+	flavors := make([]Flavor, 0)
+	err := c.cached(CacheEndpointFlavors, "flavors", &flavors, func() (interface{}, error) {
+		return c.client.ListAllFlavors()
+	})
+	return flavors, err
+}
+
+// ListAllStrains implements Client.
+func (c *CachingClient) ListAllStrains() (ListAllStrainsResult, error) {
+	strains := make(ListAllStrainsResult)
+	err := c.cached(CacheEndpointStrains, "strains", &strains, func() (interface{}, error) {
+		return c.client.ListAllStrains()
+	})
+	return strains, err
+}
+
+// SearchStrainsByName implements Client.
+func (c *CachingClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	results := make(SearchStrainsByNameResults, 0)
+	err := c.cached(CacheEndpointSearch, "search/name/"+name, &results, func() (interface{}, error) {
+		return c.client.SearchStrainsByName(name)
+	})
+	return results, err
+}
+
+// SearchStrainsByRace implements Client.
+func (c *CachingClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	results := make(SearchStrainsByRaceResults, 0)
+	err := c.cached(CacheEndpointSearch, "search/race/"+string(race), &results, func() (interface{}, error) {
+		return c.client.SearchStrainsByRace(race)
+	})
+	return results, err
+}
+
+// SearchStrainsByFlavor implements Client.
+func (c *CachingClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	results := make(SearchStrainsByFlavorResults, 0)
+	err := c.cached(CacheEndpointSearch, "search/flavor/"+string(flavor), &results, func() (interface{}, error) {
+		return c.client.SearchStrainsByFlavor(flavor)
+	})
+	return results, err
+}
+
+// SearchStrainsByEffectName implements Client.
+func (c *CachingClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	results := make(SearchStrainsByEffectNameResults, 0)
+	err := c.cached(CacheEndpointSearch, "search/effect/"+effectName, &results, func() (interface{}, error) {
+		return c.client.SearchStrainsByEffectName(effectName)
+	})
+	return results, err
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (c *CachingClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	description := ""
+	err := c.cached(CacheEndpointStrainData, "data/desc/"+strconv.Itoa(id), &description, func() (interface{}, error) {
+		return c.client.GetStrainDescriptionByStrainID(id)
+	})
+	return description, err
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (c *CachingClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	flavors := make([]Flavor, 0)
+	err := c.cached(CacheEndpointStrainData, "data/flavors/"+strconv.Itoa(id), &flavors, func() (interface{}, error) {
+		return c.client.GetStrainFlavorsByStrainID(id)
+	})
+	return flavors, err
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (c *CachingClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	effects := make(EffectsByEffectType)
+	err := c.cached(CacheEndpointStrainData, "data/effects/"+strconv.Itoa(id), &effects, func() (interface{}, error) {
+		return c.client.GetStrainEffectsByStrainID(id)
+	})
+	return effects, err
+}
+
+// SetHandleResourceRequestFunc implements Client by setting the handler
+// on the wrapped Client.  Cached results are kept; call Flush to drop them.
+func (c *CachingClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return c.client.SetHandleResourceRequestFunc(f)
+}
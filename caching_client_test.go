@@ -0,0 +1,79 @@
+package strainapiclient
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCountingTestClient(calls map[string]int) *DefaultClient {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		calls[path[strings.Index(path, "test-key")+len("test-key"):]]++
+
+		switch {
+		case strings.Contains(path, "/strains/data/effects/"):
+			return []byte("{\"positive\": [\"Relaxed\"], \"negative\": [\"Dizzy\"]}"), nil
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"]}}"), nil
+		}
+
+		return []byte("[]"), nil
+	})
+
+	return client
+}
+
+func TestCachingClientCachesResults(t *testing.T) {
+	calls := make(map[string]int)
+	var client Client = NewCachingClient(newCountingTestClient(calls), nil)
+
+	for i := 0; i < 3; i++ {
+		client.ListAllFlavors()
+		client.SearchStrainsByName("Af")
+		client.SearchStrainsByName("Bl")
+	}
+
+	expectedCalls := map[string]int{"/searchdata/flavors": 1, "/strains/search/name/Af": 1, "/strains/search/name/Bl": 1}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("Expected calls %v but got %v", expectedCalls, calls)
+	}
+}
+
+func TestCachingClientReturnsEqualResults(t *testing.T) {
+	calls := make(map[string]int)
+	defaultClient := newCountingTestClient(calls)
+	client := NewCachingClient(defaultClient, nil)
+
+	expectedEffects, _ := defaultClient.GetStrainEffectsByStrainID(1)
+	expectedStrains, _ := defaultClient.ListAllStrains()
+
+	for i := 0; i < 2; i++ {
+		if effects, _ := client.GetStrainEffectsByStrainID(1); !reflect.DeepEqual(effects, expectedEffects) {
+			t.Errorf("Expected effects %v but got %v", expectedEffects, effects)
+		}
+
+		if strains, _ := client.ListAllStrains(); !reflect.DeepEqual(strains, expectedStrains) {
+			t.Errorf("Expected strains %v but got %v", expectedStrains, strains)
+		}
+	}
+}
+
+func TestCachingClientExpiresResults(t *testing.T) {
+	calls := make(map[string]int)
+	client := NewCachingClient(newCountingTestClient(calls), CacheTTLs{CacheEndpointEffects: 20 * time.Millisecond})
+
+	client.ListAllEffects()
+	client.ListAllEffects()
+	time.Sleep(30 * time.Millisecond)
+	client.ListAllEffects()
+
+	// Flavors have no TTL so are never cached
+	client.ListAllFlavors()
+	client.ListAllFlavors()
+
+	if calls["/searchdata/effects"] != 2 || calls["/searchdata/flavors"] != 2 {
+		t.Errorf("Expected 2 calls each for effects and flavors but got %v", calls)
+	}
+}
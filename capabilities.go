@@ -0,0 +1,103 @@
+package strainapiclient
+
+// Capabilities describes the optional features of a Client, so generic code
+// can degrade gracefully, for example skipping WatchStrain on a Client that
+// never sees new data.
+type Capabilities struct {
+	// LiveData is true when results reflect the API, so refreshing or
+	// watching (WatchStrain, SubscriptionManager, Syncer) can see changes.
+	LiveData bool `json:"liveData"`
+	// Offline is true when every call is answered without network access.
+	Offline bool `json:"offline"`
+	// Cached is true when results may be served from a cache and so be up
+	// to a TTL old.
+	Cached bool `json:"cached"`
+	// Context is true when the Client implements ContextClient, so calls
+	// can be cancelled.
+	Context bool `json:"context"`
+	// Descriptions is true when ListAllStrains includes descriptions, so
+	// they can be searched locally (see StrainIndex) without fetching each
+	// strain's description.
+	Descriptions bool `json:"descriptions"`
+}
+
+// CapabilityReporter is implemented by Clients that report their
+// Capabilities.  Every Client in this package implements it.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// ClientCapabilities returns the Capabilities of client.  Clients that
+// don't implement CapabilityReporter are assumed to call the API.
+func ClientCapabilities(client Client) Capabilities {
+	if reporter, ok := client.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+
+	_, isContextClient := client.(ContextClient)
+	return Capabilities{LiveData: true, Context: isContextClient}
+}
+
+// Capabilities implements CapabilityReporter.
+func (c *DefaultClient) Capabilities() Capabilities {
+	return Capabilities{LiveData: true, Context: true}
+}
+
+// Capabilities implements CapabilityReporter.  Descriptions are reported
+// when every strain in the snapshot has one.
+func (c *FileBackedClient) Capabilities() Capabilities {
+	snapshot, _ := c.data()
+	capabilities := Capabilities{Offline: true, Descriptions: len(snapshot.Strains) > 0}
+
+	for _, strain := range snapshot.Strains {
+		if strain.Description == "" {
+			capabilities.Descriptions = false
+			break
+		}
+	}
+
+	return capabilities
+}
+
+// Capabilities implements CapabilityReporter with the capabilities of the
+// wrapped Client, plus caching.
+func (c *CachingClient) Capabilities() Capabilities {
+	capabilities := ClientCapabilities(c.client)
+	capabilities.Cached = true
+	capabilities.Context = false
+
+	return capabilities
+}
+
+// Capabilities implements CapabilityReporter with the capabilities of the
+// wrapped Client.
+func (c *CircuitBreakerClient) Capabilities() Capabilities {
+	capabilities := ClientCapabilities(c.client)
+	capabilities.Context = false
+
+	return capabilities
+}
+
+// Capabilities implements CapabilityReporter with the capabilities of the
+// wrapped Client.
+func (f *FaultyClient) Capabilities() Capabilities {
+	capabilities := ClientCapabilities(f.client)
+	capabilities.Context = false
+
+	return capabilities
+}
+
+// Capabilities implements CapabilityReporter.  Data is live and cached if
+// either Client's is, offline only if both are, and descriptions are only
+// reported when both Clients have them.
+func (c *FallbackClient) Capabilities() Capabilities {
+	primary := ClientCapabilities(c.primary)
+	fallback := ClientCapabilities(c.fallback)
+
+	return Capabilities{
+		LiveData:     primary.LiveData || fallback.LiveData,
+		Offline:      primary.Offline && fallback.Offline,
+		Cached:       primary.Cached || fallback.Cached,
+		Descriptions: primary.Descriptions && fallback.Descriptions,
+	}
+}
@@ -0,0 +1,45 @@
+package strainapiclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientCapabilities(t *testing.T) {
+	live := NewDefaultClient("test-key")
+	hydrated := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{"Afpak": {ID: 1, Description: "Earthy"}}})
+	unhydrated := NewSnapshotClient(newFakeSnapshot())
+	for name, strain := range unhydrated.snapshot.Strains {
+		strain.Description = ""
+		unhydrated.snapshot.Strains[name] = strain
+	}
+
+	for _, test := range []struct {
+		name     string
+		client   Client
+		expected Capabilities
+	}{
+		{"default", live, Capabilities{LiveData: true, Context: true}},
+		{"snapshot", hydrated, Capabilities{Offline: true, Descriptions: true}},
+		{"snapshot without descriptions", unhydrated, Capabilities{Offline: true}},
+		{"caching", NewCachingClient(live, nil), Capabilities{LiveData: true, Cached: true}},
+		{"circuit breaker", NewCircuitBreakerClient(hydrated, 5, time.Second), Capabilities{Offline: true, Descriptions: true}},
+		{"fallback", NewFallbackClient(live, hydrated), Capabilities{LiveData: true}},
+		{"fallback to cache", NewFallbackClient(live, NewCachingClient(hydrated, nil)), Capabilities{LiveData: true, Cached: true}},
+	} {
+		if actual := ClientCapabilities(test.client); actual != test.expected {
+			t.Errorf("Expected %s capabilities %+v but got %+v", test.name, test.expected, actual)
+		}
+	}
+}
+
+type bareTestClient struct {
+	Client
+}
+
+func TestClientCapabilitiesWithoutReporter(t *testing.T) {
+	expected := Capabilities{LiveData: true}
+	if actual := ClientCapabilities(bareTestClient{}); actual != expected {
+		t.Errorf("Expected %+v but got %+v", expected, actual)
+	}
+}
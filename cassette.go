@@ -0,0 +1,167 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// ErrCassetteMiss is returned when a replaying Cassette has no recorded
+// response for a request.
+var ErrCassetteMiss = newCodedError(CodeCassetteMiss, "No recorded response in cassette")
+
+// cassetteSecretPlaceholder replaces secrets (such as the API Key) in
+// recorded paths so cassettes are safe to commit.
+const cassetteSecretPlaceholder string = "{SECRET}"
+
+// CassetteInteraction is a single recorded request and its response.
+type CassetteInteraction struct {
+	Path       string `json:"path"`
+	Body       string `json:"body,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Cassette records API responses and replays them deterministically, for
+// reproducible tests and offline development.  Wire it into a DefaultClient
+// with SetHandleResourceRequestFunc:
+//
+//	cassette := NewCassette(apiKey)
+//	client.SetHandleResourceRequestFunc(cassette.Record(client.SetHandleResourceRequestFunc(nil)))
+//	... make calls, then cassette.Save("testdata/cassette.json")
+//
+//	cassette, _ := LoadCassette("testdata/cassette.json", apiKey)
+//	client.SetHandleResourceRequestFunc(cassette.Replay())
+//
+// Any secrets passed when creating or loading the Cassette are replaced in
+// recorded paths, so the API Key never ends up in the file.
+type Cassette struct {
+	mutex        sync.Mutex
+	secrets      []string
+	Interactions []CassetteInteraction `json:"interactions"`
+	replayed     map[string]int
+}
+
+// NewCassette creates a new, empty Cassette that redacts secrets.
+func NewCassette(secrets ...string) *Cassette {
+	return &Cassette{
+		secrets:      secrets,
+		Interactions: make([]CassetteInteraction, 0),
+		replayed:     make(map[string]int),
+	}
+}
+
+// LoadCassette reads a Cassette saved at path; secrets are redacted from
+// requested paths before they are looked up.
+func LoadCassette(path string, secrets ...string) (*Cassette, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Problem reading cassette %s: %w", path, err)
+	}
+
+	cassette := NewCassette(secrets...)
+	if err := json.Unmarshal(contents, cassette); err != nil {
+		return nil, fmt.Errorf("Problem parsing cassette %s: %w", path, err)
+	}
+
+	return cassette, nil
+}
+
+// Save writes the recorded interactions to path as JSON.
+func (c *Cassette) Save(path string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Problem encoding cassette: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		return fmt.Errorf("Problem writing cassette %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Cassette) redact(path string) string {
+	return redactSecrets(path, c.secrets, cassetteSecretPlaceholder)
+}
+
+// redactSecrets replaces every secret in value with placeholder.
+func redactSecrets(value string, secrets []string, placeholder string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			value = strings.Replace(value, secret, placeholder, -1)
+		}
+	}
+
+	return value
+}
+
+// Record returns a HandleResourceRequestFunc that calls next and records
+// each request and its response (including errors) in the Cassette.
+func (c *Cassette) Record(next HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return func(resourcePath string) ([]byte, error) {
+		body, err := next(resourcePath)
+
+		interaction := CassetteInteraction{Path: c.redact(resourcePath), Body: string(body)}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			interaction.StatusCode = apiErr.StatusCode
+			interaction.Body = apiErr.Body
+		} else if err != nil {
+			interaction.Error = err.Error()
+		}
+
+		c.mutex.Lock()
+		c.Interactions = append(c.Interactions, interaction)
+		c.mutex.Unlock()
+
+		return body, err
+	}
+}
+
+// Replay returns a HandleResourceRequestFunc that serves the recorded
+// responses.  Requests for the same path are answered in the order they
+// were recorded, with the last response repeated once they run out.
+// Paths that were never recorded fail with ErrCassetteMiss.
+func (c *Cassette) Replay() HandleResourceRequestFunc {
+	return func(resourcePath string) ([]byte, error) {
+		path := c.redact(resourcePath)
+
+		c.mutex.Lock()
+		matches := make([]CassetteInteraction, 0)
+		for _, interaction := range c.Interactions {
+			if interaction.Path == path {
+				matches = append(matches, interaction)
+			}
+		}
+
+		if len(matches) == 0 {
+			c.mutex.Unlock()
+			return make([]byte, 0), fmt.Errorf("%w: %s", ErrCassetteMiss, path)
+		}
+
+		index := c.replayed[path]
+		if index >= len(matches) {
+			index = len(matches) - 1
+		}
+		c.replayed[path]++
+		c.mutex.Unlock()
+
+		interaction := matches[index]
+		switch {
+		case interaction.StatusCode != 0:
+			return make([]byte, 0), &APIError{StatusCode: interaction.StatusCode, Body: interaction.Body}
+		case interaction.Error != "":
+			return make([]byte, 0), errors.New(interaction.Error)
+		}
+
+		return []byte(interaction.Body), nil
+	}
+}
@@ -0,0 +1,64 @@
+package strainapiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-cassette")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	cassettePath := filepath.Join(dir, "cassette.json")
+
+	apiKey := "secret-key"
+	recordingClient := NewDefaultClient(apiKey)
+	cassette := NewCassette(apiKey)
+	recordingClient.SetHandleResourceRequestFunc(cassette.Record(func(path string) ([]byte, error) {
+		if strings.Contains(path, "/strains/data/") {
+			return make([]byte, 0), &APIError{StatusCode: 404, Body: "missing"}
+		}
+		return []byte("[\"Earthy\", \"Pine\"]"), nil
+	}))
+
+	expectedFlavors, _ := recordingClient.ListAllFlavors()
+	recordingClient.GetStrainFlavorsByStrainID(1)
+
+	if err := cassette.Save(cassettePath); err != nil {
+		t.Errorf("Expected no error saving but got: %s", err)
+	}
+
+	contents, _ := ioutil.ReadFile(cassettePath)
+	if strings.Contains(string(contents), apiKey) {
+		t.Error("Expected the API Key to be redacted from the cassette")
+	}
+
+	replayed, err := LoadCassette(cassettePath, apiKey)
+	if err != nil {
+		t.Errorf("Expected no error loading but got: %s", err)
+		return
+	}
+
+	replayingClient := NewDefaultClient(apiKey)
+	replayingClient.SetHandleResourceRequestFunc(replayed.Replay())
+
+	if flavors, err := replayingClient.ListAllFlavors(); err != nil || !reflect.DeepEqual(flavors, expectedFlavors) {
+		t.Errorf("Expected replayed flavors %v but got %v (error: %v)", expectedFlavors, flavors, err)
+	}
+
+	if _, err := replayingClient.GetStrainFlavorsByStrainID(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the recorded 404 to replay as ErrNotFound but got %v", err)
+	}
+
+	if _, err := replayingClient.ListAllEffects(); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("Expected ErrCassetteMiss for an unrecorded request but got %v", err)
+	}
+}
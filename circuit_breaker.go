@@ -0,0 +1,218 @@
+package strainapiclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a CircuitBreakerClient while it is failing fast.
+var ErrCircuitOpen = newCodedError(CodeCircuitOpen, "Circuit breaker is open")
+
+// CircuitBreakerState is the state of a CircuitBreakerClient.
+type CircuitBreakerState string
+
+// The valid values of CircuitBreakerState
+const (
+	// CircuitBreakerClosed means calls are passed through to the wrapped Client
+	CircuitBreakerClosed CircuitBreakerState = "closed"
+	// CircuitBreakerOpen means calls fail fast with ErrCircuitOpen
+	CircuitBreakerOpen = "open"
+	// CircuitBreakerHalfOpen means a single trial call is allowed through
+	CircuitBreakerHalfOpen = "half-open"
+)
+
+// CircuitBreakerClient is a Client that wraps another Client and trips open
+// after a number of consecutive failures, failing fast with ErrCircuitOpen
+// for a cooldown window so batch pipelines don't hammer a down API.  After
+// the cooldown a single trial call is let through: if it succeeds the
+// breaker closes, otherwise it opens for another cooldown window.
+//
+// ErrNotFound errors don't count as failures since they mean the API is up.
+type CircuitBreakerClient struct {
+	client           Client
+	failureThreshold int
+	cooldown         time.Duration
+
+	mutex               sync.Mutex
+	state               CircuitBreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+// NewCircuitBreakerClient creates a new CircuitBreakerClient wrapping client
+// that opens after failureThreshold consecutive failures for cooldown.
+func NewCircuitBreakerClient(client Client, failureThreshold int, cooldown time.Duration) *CircuitBreakerClient {
+	return &CircuitBreakerClient{
+		client:           client,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		state:            CircuitBreakerClosed,
+	}
+}
+
+// State returns the current CircuitBreakerState.
+func (c *CircuitBreakerClient) State() CircuitBreakerState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == CircuitBreakerOpen && time.Since(c.openedAt) >= c.cooldown {
+		return CircuitBreakerHalfOpen
+	}
+
+	return c.state
+}
+
+// allow returns ErrCircuitOpen if the call should fail fast.
+func (c *CircuitBreakerClient) allow() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch c.state {
+	case CircuitBreakerOpen:
+		if time.Since(c.openedAt) < c.cooldown {
+			return ErrCircuitOpen
+		}
+		c.state = CircuitBreakerHalfOpen
+		return nil
+	case CircuitBreakerHalfOpen:
+		// A trial call is already in flight
+		return ErrCircuitOpen
+	}
+
+	return nil
+}
+
+// record updates the breaker with the result of a call.
+func (c *CircuitBreakerClient) record(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err == nil || errors.Is(err, ErrNotFound) {
+		c.consecutiveFailures = 0
+		c.state = CircuitBreakerClosed
+		return
+	}
+
+	c.consecutiveFailures++
+	if c.state == CircuitBreakerHalfOpen || c.consecutiveFailures >= c.failureThreshold {
+		c.state = CircuitBreakerOpen
+		c.openedAt = time.Now()
+	}
+}
+
+// ListAllEffects implements Client.
+func (c *CircuitBreakerClient) ListAllEffects() ([]Effect, error) {
+	if err := c.allow(); err != nil {
+		return make([]Effect, 0), err
+	}
+
+	effects, err := c.client.ListAllEffects()
+	c.record(err)
+	return effects, err
+}
+
+// ListAllFlavors implements Client.
+func (c *CircuitBreakerClient) ListAllFlavors() ([]Flavor, error) {
+	if err := c.allow(); err != nil {
+		return make([]Flavor, 0), err
+	}
+
+	flavors, err := c.client.ListAllFlavors()
+	c.record(err)
+	return flavors, err
+}
+
+// ListAllStrains implements Client.
+func (c *CircuitBreakerClient) ListAllStrains() (ListAllStrainsResult, error) {
+	if err := c.allow(); err != nil {
+		return make(ListAllStrainsResult), err
+	}
+
+	strains, err := c.client.ListAllStrains()
+	c.record(err)
+	return strains, err
+}
+
+// SearchStrainsByName implements Client.
+func (c *CircuitBreakerClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	if err := c.allow(); err != nil {
+		return make(SearchStrainsByNameResults, 0), err
+	}
+
+	results, err := c.client.SearchStrainsByName(name)
+	c.record(err)
+	return results, err
+}
+
+// SearchStrainsByRace implements Client.
+func (c *CircuitBreakerClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	if err := c.allow(); err != nil {
+		return make(SearchStrainsByRaceResults, 0), err
+	}
+
+	results, err := c.client.SearchStrainsByRace(race)
+	c.record(err)
+	return results, err
+}
+
+// SearchStrainsByFlavor implements Client.
+func (c *CircuitBreakerClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	if err := c.allow(); err != nil {
+		return make(SearchStrainsByFlavorResults, 0), err
+	}
+
+	results, err := c.client.SearchStrainsByFlavor(flavor)
+	c.record(err)
+	return results, err
+}
+
+// SearchStrainsByEffectName implements Client.
+func (c *CircuitBreakerClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	if err := c.allow(); err != nil {
+		return make(SearchStrainsByEffectNameResults, 0), err
+	}
+
+	results, err := c.client.SearchStrainsByEffectName(effectName)
+	c.record(err)
+	return results, err
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (c *CircuitBreakerClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	if err := c.allow(); err != nil {
+		return "", err
+	}
+
+	description, err := c.client.GetStrainDescriptionByStrainID(id)
+	c.record(err)
+	return description, err
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (c *CircuitBreakerClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	if err := c.allow(); err != nil {
+		return make([]Flavor, 0), err
+	}
+
+	flavors, err := c.client.GetStrainFlavorsByStrainID(id)
+	c.record(err)
+	return flavors, err
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (c *CircuitBreakerClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	if err := c.allow(); err != nil {
+		return make(EffectsByEffectType), err
+	}
+
+	effects, err := c.client.GetStrainEffectsByStrainID(id)
+	c.record(err)
+	return effects, err
+}
+
+// SetHandleResourceRequestFunc implements Client by setting the handler
+// on the wrapped Client.
+func (c *CircuitBreakerClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return c.client.SetHandleResourceRequestFunc(f)
+}
@@ -0,0 +1,54 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerClientTripsAndRecovers(t *testing.T) {
+	defaultClient := NewDefaultClient("test-key")
+	failing := true
+	calls := 0
+	defaultClient.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		calls++
+		if failing {
+			return make([]byte, 0), &APIError{StatusCode: 503}
+		}
+		return []byte("[]"), nil
+	})
+
+	var client Client = NewCircuitBreakerClient(defaultClient, 2, 50*time.Millisecond)
+	breaker := client.(*CircuitBreakerClient)
+
+	client.ListAllFlavors()
+	client.ListAllFlavors()
+
+	if _, err := client.ListAllFlavors(); !errors.Is(err, ErrCircuitOpen) || calls != 2 {
+		t.Errorf("Expected ErrCircuitOpen after 2 failures without another call; got %v after %d calls", err, calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if state := breaker.State(); state != CircuitBreakerHalfOpen {
+		t.Errorf("Expected the breaker to be half-open after the cooldown but it was %s", state)
+	}
+
+	failing = false
+	if _, err := client.ListAllFlavors(); err != nil || breaker.State() != CircuitBreakerClosed {
+		t.Errorf("Expected the trial call to succeed and close the breaker; got %v with state %s", err, breaker.State())
+	}
+}
+
+func TestCircuitBreakerClientIgnoresNotFound(t *testing.T) {
+	defaultClient := NewDefaultClient("test-key")
+	defaultClient.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 404}
+	})
+
+	client := NewCircuitBreakerClient(defaultClient, 1, time.Minute)
+	client.GetStrainFlavorsByStrainID(1)
+
+	if state := client.State(); state != CircuitBreakerClosed {
+		t.Errorf("Expected not found errors to leave the breaker closed but it was %s", state)
+	}
+}
@@ -0,0 +1,468 @@
+// Command strainctl queries the Strain API from the shell.
+//
+// Usage:
+//
+//	strainctl [-output table|json] [-base-url url] effects
+//	strainctl [-output table|json] [-base-url url] flavors
+//	strainctl [-output table|json] [-base-url url] strains list
+//	strainctl [-output table|json] [-base-url url] strains search -name|-race|-effect|-flavor value
+//	strainctl [-output table|json] [-base-url url] strains query <query>
+//	strainctl [-output table|json] [-base-url url] strains show <id>
+//	strainctl [-output table|json] [-base-url url] verify [-file dataset.json [-repair]]
+//	strainctl [-output table|json] [-base-url url] coverage [-csv]
+//	strainctl [-output table|json] [-base-url url] snapshot download|verify [-dir dir]
+//
+// The API Key is read from the STRAIN_API_KEY environment variable.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+func main() {
+	flag.Usage = usage
+	output := flag.String("output", "table", "output format: table or json")
+	baseURL := flag.String("base-url", "", "base URL of the API, proxy, or fake server (defaults to the real API)")
+	flag.Parse()
+
+	if *output != "table" && *output != "json" {
+		log.Fatalf("Unknown output format '%s'; expected table or json", *output)
+	}
+
+	const apiEnvironmentVariableName string = "STRAIN_API_KEY"
+	apiKey, found := os.LookupEnv(apiEnvironmentVariableName)
+	if !found {
+		log.Fatalf("Did not find envrionment variable '%s'", apiEnvironmentVariableName)
+	}
+
+	opts := make([]strainapiclient.Option, 0)
+	if *baseURL != "" {
+		opts = append(opts, strainapiclient.WithBaseURL(*baseURL))
+	}
+	client, err := strainapiclient.NewValidatedClient(apiKey, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := printer{out: os.Stdout, json: *output == "json"}
+	if err := run(client, p, flag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: strainctl [flags] <command>
+
+Commands:
+  effects                                      list all effects
+  flavors                                      list all flavors
+  strains list                                 list all strains
+  strains search -name|-race|-effect|-flavor   search strains
+  strains query <query>                        search with a query like "race:hybrid effect:relaxed -effect:paranoid"
+  strains show <id>                            show a strain's description, flavors, and effects
+  verify [-file dataset.json [-repair]]        check a dataset for impossible values
+  coverage [-csv]                              list strains missing descriptions, flavors, or effects
+  snapshot download|verify [-dir dir]          download a checksummed snapshot or verify the latest
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+// run dispatches args to the matching command.
+func run(client strainapiclient.Client, p printer, args []string) error {
+	if len(args) == 0 {
+		usage()
+		return fmt.Errorf("Missing command")
+	}
+
+	switch args[0] {
+	case "effects":
+		return listEffects(client, p)
+	case "flavors":
+		return listFlavors(client, p)
+	case "strains":
+		if len(args) < 2 {
+			return fmt.Errorf("Missing strains subcommand; expected list, search, query, or show")
+		}
+		switch args[1] {
+		case "list":
+			return listStrains(client, p)
+		case "search":
+			return searchStrains(client, p, args[2:])
+		case "query":
+			return queryStrains(client, p, args[2:])
+		case "show":
+			return showStrain(client, p, args[2:])
+		}
+		return fmt.Errorf("Unknown strains subcommand '%s'; expected list, search, query, or show", args[1])
+	case "verify":
+		return verify(client, p, args[1:])
+	case "coverage":
+		return coverage(client, p, args[1:])
+	case "snapshot":
+		return snapshot(client, p, args[1:])
+	}
+
+	return fmt.Errorf("Unknown command '%s'", args[0])
+}
+
+func listEffects(client strainapiclient.Client, p printer) error {
+	effects, err := client.ListAllEffects()
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0)
+	for _, effect := range effects {
+		rows = append(rows, []string{effect.Name, string(effect.Type)})
+	}
+
+	return p.print(effects, []string{"EFFECT", "TYPE"}, rows)
+}
+
+func listFlavors(client strainapiclient.Client, p printer) error {
+	flavors, err := client.ListAllFlavors()
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0)
+	for _, flavor := range flavors {
+		rows = append(rows, []string{string(flavor)})
+	}
+
+	return p.print(flavors, []string{"FLAVOR"}, rows)
+}
+
+func listStrains(client strainapiclient.Client, p printer) error {
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0)
+	for name := range strains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0)
+	for _, name := range names {
+		strain := strains[name]
+		rows = append(rows, []string{strconv.Itoa(strain.ID), strain.Name, string(strain.Race)})
+	}
+
+	return p.print(strains, []string{"ID", "NAME", "RACE"}, rows)
+}
+
+func searchStrains(client strainapiclient.Client, p printer, args []string) error {
+	flags := flag.NewFlagSet("strains search", flag.ContinueOnError)
+	name := flags.String("name", "", "search by strain name")
+	race := flags.String("race", "", "search by race: indica, sativa, or hybrid")
+	effect := flags.String("effect", "", "search by effect name")
+	flavor := flags.String("flavor", "", "search by flavor")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	header := []string{"ID", "NAME", "RACE"}
+	rows := make([][]string, 0)
+
+	switch {
+	case *name != "":
+		results, err := client.SearchStrainsByName(*name)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			rows = append(rows, []string{strconv.Itoa(result.ID), result.Name, string(result.Race)})
+		}
+		return p.print(results, header, rows)
+	case *race != "":
+		results, err := client.SearchStrainsByRace(strainapiclient.Race(*race))
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			rows = append(rows, []string{strconv.Itoa(result.ID), result.Name, string(result.Race)})
+		}
+		return p.print(results, header, rows)
+	case *effect != "":
+		results, err := client.SearchStrainsByEffectName(*effect)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			rows = append(rows, []string{strconv.Itoa(result.ID), result.Name, string(result.Race)})
+		}
+		return p.print(results, header, rows)
+	case *flavor != "":
+		results, err := client.SearchStrainsByFlavor(strainapiclient.Flavor(*flavor))
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			rows = append(rows, []string{strconv.Itoa(result.ID), result.Name, string(result.Race)})
+		}
+		return p.print(results, header, rows)
+	}
+
+	return fmt.Errorf("Missing search criteria; expected one of -name, -race, -effect, or -flavor")
+}
+
+// verify checks the live dataset, or one saved with "strains list" in JSON
+// output, for integrity issues and optionally repairs the saved file.
+func verify(client strainapiclient.Client, p printer, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	file := flags.String("file", "", "dataset saved with -output json strains list (defaults to the live API)")
+	repair := flags.Bool("repair", false, "rewrite -file with the issues repaired")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *repair && *file == "" {
+		return fmt.Errorf("-repair requires -file")
+	}
+
+	var strains strainapiclient.ListAllStrainsResult
+	if *file == "" {
+		var err error
+		if strains, err = client.ListAllStrains(); err != nil {
+			return err
+		}
+	} else {
+		contents, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("Problem reading dataset %s: %w", *file, err)
+		}
+		if marshallErr := json.Unmarshal(contents, &strains); marshallErr != nil {
+			return fmt.Errorf("Problem parsing dataset %s: %w", *file, marshallErr)
+		}
+	}
+
+	issues := strainapiclient.CheckIntegrity(strains)
+
+	rows := make([][]string, 0)
+	for _, issue := range issues {
+		rows = append(rows, []string{issue.Key, strconv.Itoa(issue.ID), string(issue.Problem), issue.Detail})
+	}
+	if err := p.print(issues, []string{"KEY", "ID", "PROBLEM", "DETAIL"}, rows); err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	if !*repair {
+		return fmt.Errorf("Found %d integrity issues", len(issues))
+	}
+
+	contents, marshallErr := json.MarshalIndent(strainapiclient.RepairIntegrity(strains), "", "  ")
+	if marshallErr != nil {
+		return fmt.Errorf("Problem encoding repaired dataset: %w", marshallErr)
+	}
+
+	if err := ioutil.WriteFile(*file, contents, 0644); err != nil {
+		return fmt.Errorf("Problem writing repaired dataset %s: %w", *file, err)
+	}
+
+	return nil
+}
+
+// coverage reports the strains missing data, optionally as CSV.  The listing
+// doesn't include descriptions, so each strain is hydrated first.
+func coverage(client strainapiclient.Client, p printer, args []string) error {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	asCSV := flags.Bool("csv", false, "write the report as CSV")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return err
+	}
+
+	strains, err = strainapiclient.HydrateStrains(context.Background(), client, strains, strainapiclient.DefaultBatchConcurrency)
+	if err != nil {
+		return fmt.Errorf("Problem fetching strain descriptions: %w", err)
+	}
+
+	report := strainapiclient.BuildCoverageReport(strains)
+	if *asCSV {
+		return report.WriteCSV(p.out)
+	}
+
+	rows := make([][]string, 0)
+	for _, gap := range report {
+		effectTypes := make([]string, 0)
+		for _, effectType := range gap.MissingEffectTypes {
+			effectTypes = append(effectTypes, string(effectType))
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(gap.ID),
+			gap.Name,
+			strconv.FormatBool(gap.MissingDescription),
+			strconv.FormatBool(gap.MissingFlavors),
+			strings.Join(effectTypes, ", "),
+		})
+	}
+
+	return p.print(report, []string{"ID", "NAME", "NO DESCRIPTION", "NO FLAVORS", "MISSING EFFECTS"}, rows)
+}
+
+// snapshot downloads a new snapshot or verifies the latest one in a directory.
+func snapshot(client strainapiclient.Client, p printer, args []string) error {
+	if len(args) == 0 || (args[0] != "download" && args[0] != "verify") {
+		return fmt.Errorf("Expected snapshot download or snapshot verify")
+	}
+
+	flags := flag.NewFlagSet("snapshot "+args[0], flag.ContinueOnError)
+	dir := flags.String("dir", ".", "directory snapshots are kept in")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	downloader := strainapiclient.NewSnapshotDownloader(client, *dir)
+
+	var manifest strainapiclient.SnapshotManifest
+	if args[0] == "download" {
+		var err error
+		if manifest, err = downloader.Download(); err != nil {
+			return err
+		}
+	} else {
+		latest, err := downloader.Latest()
+		if err != nil {
+			return err
+		}
+		if _, manifest, err = strainapiclient.LoadVerifiedSnapshot(latest); err != nil {
+			return err
+		}
+	}
+
+	rows := [][]string{
+		{"VERSION", manifest.Version},
+		{"FILE", manifest.File},
+		{"SHA256", manifest.SHA256},
+		{"STRAINS", strconv.Itoa(manifest.Strains)},
+	}
+
+	return p.print(manifest, nil, rows)
+}
+
+// strainDetails is what strains show prints.
+type strainDetails struct {
+	ID          int                                 `json:"id"`
+	Description string                              `json:"desc"`
+	Flavors     []strainapiclient.Flavor            `json:"flavors"`
+	Effects     strainapiclient.EffectsByEffectType `json:"effects"`
+}
+
+func queryStrains(client strainapiclient.Client, p printer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("Missing query")
+	}
+
+	query, err := strainapiclient.ParseStrainQuery(client, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	strains, err := query.Run(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0)
+	for _, strain := range strains {
+		rows = append(rows, []string{strconv.Itoa(strain.ID), strain.Name, string(strain.Race)})
+	}
+
+	return p.print(strains, []string{"ID", "NAME", "RACE"}, rows)
+}
+
+func showStrain(client strainapiclient.Client, p printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Expected a single strain ID")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("Invalid strain ID '%s': %w", args[0], err)
+	}
+
+	details := strainDetails{ID: id}
+
+	if details.Description, err = client.GetStrainDescriptionByStrainID(id); err != nil {
+		return err
+	}
+	if details.Flavors, err = client.GetStrainFlavorsByStrainID(id); err != nil {
+		return err
+	}
+	if details.Effects, err = client.GetStrainEffectsByStrainID(id); err != nil {
+		return err
+	}
+
+	flavors := make([]string, 0)
+	for _, flavor := range details.Flavors {
+		flavors = append(flavors, string(flavor))
+	}
+
+	rows := [][]string{
+		{"ID", strconv.Itoa(id)},
+		{"DESCRIPTION", details.Description},
+		{"FLAVORS", strings.Join(flavors, ", ")},
+	}
+	for _, effectType := range []strainapiclient.EffectType{strainapiclient.EffectTypePositive, strainapiclient.EffectTypeNegative, strainapiclient.EffectTypeMedical} {
+		names := make([]string, 0)
+		for _, effect := range details.Effects[effectType] {
+			names = append(names, effect.Name)
+		}
+		rows = append(rows, []string{strings.ToUpper(string(effectType)) + " EFFECTS", strings.Join(names, ", ")})
+	}
+
+	return p.print(details, nil, rows)
+}
+
+// printer writes command results as an aligned table or as JSON.
+type printer struct {
+	out  io.Writer
+	json bool
+}
+
+// print writes value as JSON, or header and rows as a table.  A nil header
+// prints the rows without one.
+func (p printer) print(value interface{}, header []string, rows [][]string) error {
+	if p.json {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(writer, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
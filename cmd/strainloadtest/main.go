@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/tchype/strainapiclient-go"
+	"github.com/tchype/strainapiclient-go/loadtest"
+)
+
+func main() {
+	baseURL := flag.String("base-url", "", "base URL of the API, proxy, or fake server to test (defaults to the real API)")
+	mixName := flag.String("mix", "search", "query mix to replay: search or hydrate")
+	concurrency := flag.Int("concurrency", 4, "number of concurrent workers")
+	duration := flag.Duration("duration", 30*time.Second, "how long to run the test")
+	requests := flag.Int("requests", 0, "stop after this many requests (0 for no limit)")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for picking operations and parameters")
+	flag.Parse()
+
+	const apiEnvironmentVariableName string = "STRAIN_API_KEY"
+	apiKey, found := os.LookupEnv(apiEnvironmentVariableName)
+	if !found {
+		log.Fatalf("Did not find envrionment variable '%s'", apiEnvironmentVariableName)
+	}
+
+	var mix loadtest.Mix
+	switch *mixName {
+	case "search":
+		mix = loadtest.SearchHeavyMix()
+	case "hydrate":
+		mix = loadtest.HydrateHeavyMix()
+	default:
+		log.Fatalf("Unknown mix '%s'; expected search or hydrate", *mixName)
+	}
+
+	opts := make([]strainapiclient.Option, 0)
+	if *baseURL != "" {
+		opts = append(opts, strainapiclient.WithBaseURL(*baseURL))
+	}
+	client, err := strainapiclient.NewValidatedClient(apiKey, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := loadtest.Run(context.Background(), client, loadtest.Config{
+		Mix:         mix,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Requests:    *requests,
+		Seed:        *seed,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Requests: %d  Errors: %d  Elapsed: %s  Throughput: %.1f req/s\n",
+		report.Requests, report.Errors, report.Elapsed.Round(time.Millisecond), report.Throughput)
+	printLatencies("all", report.LatencyReport)
+
+	names := make([]string, 0)
+	for name := range report.ByOperation {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		printLatencies(name, report.ByOperation[name])
+	}
+}
+
+func printLatencies(name string, report loadtest.LatencyReport) {
+	fmt.Printf("%-32s n=%-6d errors=%-6d p50=%-10s p90=%-10s p99=%-10s max=%s\n",
+		name, report.Requests, report.Errors, report.P50, report.P90, report.P99, report.Max)
+}
@@ -0,0 +1,284 @@
+package strainapiclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// CompositePolicy is where a CompositeClient answers a class of calls from.
+type CompositePolicy string
+
+// The valid values of CompositePolicy
+const (
+	// CompositeLocalFirst answers from the local store and falls back to
+	// the API when the store has no result (ErrNotFound or an empty result).
+	CompositeLocalFirst CompositePolicy = "local-first"
+	// CompositeLocalOnly only answers from the local store.
+	CompositeLocalOnly = "local-only"
+	// CompositeRemoteOnly only answers from the API.
+	CompositeRemoteOnly = "remote-only"
+)
+
+// CompositePolicies is the CompositePolicy for each class of calls, using
+// the same classes as CachingClient.  Classes with no policy are
+// CompositeLocalFirst.
+type CompositePolicies map[CacheEndpoint]CompositePolicy
+
+// CompositeClient is an offline-first Client: it answers from a local
+// FileBackedClient when it can and transparently calls the live API for
+// misses.  With backfill on, strains listed by the API are merged into the
+// store (see MergeStrain) and per-strain data for strains already in the
+// store is saved to it, so the next call for them is answered locally.
+// Strains added with AddLocalStrain are never looked up in the API.
+type CompositeClient struct {
+	local    *FileBackedClient
+	remote   Client
+	backfill bool
+
+	mutex    sync.Mutex
+	policies CompositePolicies
+
+	decisions decisionHooks
+}
+
+// NewCompositeClient creates a new CompositeClient answering from local and
+// falling back to remote.  If backfill is true, data fetched from remote is
+// saved to local.
+func NewCompositeClient(local *FileBackedClient, remote Client, backfill bool) *CompositeClient {
+	return &CompositeClient{local: local, remote: remote, backfill: backfill, policies: make(CompositePolicies)}
+}
+
+// SetPolicy sets the CompositePolicy for endpoint and returns the previous
+// one.
+func (c *CompositeClient) SetPolicy(endpoint CacheEndpoint, policy CompositePolicy) CompositePolicy {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	current := c.policy(endpoint)
+	c.policies[endpoint] = policy
+	return current
+}
+
+// policy returns the CompositePolicy for endpoint; the caller holds mutex.
+func (c *CompositeClient) policy(endpoint CacheEndpoint) CompositePolicy {
+	if policy, found := c.policies[endpoint]; found {
+		return policy
+	}
+
+	return CompositeLocalFirst
+}
+
+// useLocal and useRemote report which Clients the policy for endpoint
+// allows.
+func (c *CompositeClient) useLocal(endpoint CacheEndpoint) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.policy(endpoint) != CompositeRemoteOnly
+}
+
+func (c *CompositeClient) useRemote(endpoint CacheEndpoint) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.policy(endpoint) != CompositeLocalOnly
+}
+
+// isLocalMiss reports whether a local call found nothing.
+func isLocalMiss(err error, empty bool) bool {
+	return errors.Is(err, ErrNotFound) || (err == nil && empty)
+}
+
+// answeredLocally reports whether the local result of method should be
+// returned: when it isn't a miss or the policy for endpoint doesn't allow
+// calling the API.  Otherwise it traces the decision to call the API.
+func (c *CompositeClient) answeredLocally(endpoint CacheEndpoint, method string, err error, empty bool) bool {
+	if !isLocalMiss(err, empty) || !c.useRemote(endpoint) {
+		return true
+	}
+
+	reason := "no local result"
+	if err != nil {
+		reason = err.Error()
+	}
+	c.decisions.emit(DecisionLiveFallback, "CompositeClient", method, reason)
+
+	return false
+}
+
+// backfillStrain applies update to the stored strain with id, if backfill
+// is on and the store has that strain.
+func (c *CompositeClient) backfillStrain(method string, id int, update func(strain *Strain)) {
+	if c.backfill {
+		c.local.updateStrain(id, update)
+		c.decisions.emit(DecisionBackfill, "CompositeClient", method, fmt.Sprintf("strain with ID %d", id))
+	}
+}
+
+// ListAllEffects implements Client.
+func (c *CompositeClient) ListAllEffects() ([]Effect, error) {
+	if c.useLocal(CacheEndpointEffects) {
+		effects, err := c.local.ListAllEffects()
+		if c.answeredLocally(CacheEndpointEffects, "ListAllEffects", err, len(effects) == 0) {
+			return effects, err
+		}
+	}
+
+	return c.remote.ListAllEffects()
+}
+
+// ListAllFlavors implements Client.
+func (c *CompositeClient) ListAllFlavors() ([]Flavor, error) {
+	if c.useLocal(CacheEndpointFlavors) {
+		flavors, err := c.local.ListAllFlavors()
+		if c.answeredLocally(CacheEndpointFlavors, "ListAllFlavors", err, len(flavors) == 0) {
+			return flavors, err
+		}
+	}
+
+	return c.remote.ListAllFlavors()
+}
+
+// ListAllStrains implements Client.
+func (c *CompositeClient) ListAllStrains() (ListAllStrainsResult, error) {
+	if c.useLocal(CacheEndpointStrains) {
+		strains, err := c.local.ListAllStrains()
+		if c.answeredLocally(CacheEndpointStrains, "ListAllStrains", err, len(strains) == 0) {
+			return strains, err
+		}
+	}
+
+	strains, err := c.remote.ListAllStrains()
+	if err == nil && c.backfill {
+		batch := make([]Strain, 0)
+		for name, strain := range strains {
+			if strain.Name == "" {
+				strain.Name = name
+			}
+			batch = append(batch, strain)
+		}
+		c.local.MergeStrain(batch...)
+		c.decisions.emit(DecisionBackfill, "CompositeClient", "ListAllStrains", fmt.Sprintf("%d strains", len(batch)))
+	}
+
+	return strains, err
+}
+
+// SearchStrainsByName implements Client.
+func (c *CompositeClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	if c.useLocal(CacheEndpointSearch) {
+		results, err := c.local.SearchStrainsByName(name)
+		if c.answeredLocally(CacheEndpointSearch, "SearchStrainsByName", err, len(results) == 0) {
+			return results, err
+		}
+	}
+
+	return c.remote.SearchStrainsByName(name)
+}
+
+// SearchStrainsByRace implements Client.
+func (c *CompositeClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	if c.useLocal(CacheEndpointSearch) {
+		results, err := c.local.SearchStrainsByRace(race)
+		if c.answeredLocally(CacheEndpointSearch, "SearchStrainsByRace", err, len(results) == 0) {
+			return results, err
+		}
+	}
+
+	return c.remote.SearchStrainsByRace(race)
+}
+
+// SearchStrainsByFlavor implements Client.
+func (c *CompositeClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	if c.useLocal(CacheEndpointSearch) {
+		results, err := c.local.SearchStrainsByFlavor(flavor)
+		if c.answeredLocally(CacheEndpointSearch, "SearchStrainsByFlavor", err, len(results) == 0) {
+			return results, err
+		}
+	}
+
+	return c.remote.SearchStrainsByFlavor(flavor)
+}
+
+// SearchStrainsByEffectName implements Client.
+func (c *CompositeClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	if c.useLocal(CacheEndpointSearch) {
+		results, err := c.local.SearchStrainsByEffectName(effectName)
+		if c.answeredLocally(CacheEndpointSearch, "SearchStrainsByEffectName", err, len(results) == 0) {
+			return results, err
+		}
+	}
+
+	return c.remote.SearchStrainsByEffectName(effectName)
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (c *CompositeClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	if c.useLocal(CacheEndpointStrainData) {
+		description, err := c.local.GetStrainDescriptionByStrainID(id)
+		if IsLocalStrainID(id) || c.answeredLocally(CacheEndpointStrainData, "GetStrainDescriptionByStrainID", err, description == "") {
+			return description, err
+		}
+	}
+
+	description, err := c.remote.GetStrainDescriptionByStrainID(id)
+	if err == nil {
+		c.backfillStrain("GetStrainDescriptionByStrainID", id, func(strain *Strain) { strain.Description = description })
+	}
+
+	return description, err
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (c *CompositeClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	if c.useLocal(CacheEndpointStrainData) {
+		flavors, err := c.local.GetStrainFlavorsByStrainID(id)
+		if IsLocalStrainID(id) || c.answeredLocally(CacheEndpointStrainData, "GetStrainFlavorsByStrainID", err, len(flavors) == 0) {
+			return flavors, err
+		}
+	}
+
+	flavors, err := c.remote.GetStrainFlavorsByStrainID(id)
+	if err == nil && len(flavors) > 0 {
+		c.backfillStrain("GetStrainFlavorsByStrainID", id, func(strain *Strain) { strain.Flavors = append(make([]Flavor, 0), flavors...) })
+	}
+
+	return flavors, err
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (c *CompositeClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	if c.useLocal(CacheEndpointStrainData) {
+		effects, err := c.local.GetStrainEffectsByStrainID(id)
+		if IsLocalStrainID(id) || c.answeredLocally(CacheEndpointStrainData, "GetStrainEffectsByStrainID", err, len(effects) == 0) {
+			return effects, err
+		}
+	}
+
+	effects, err := c.remote.GetStrainEffectsByStrainID(id)
+	if err == nil && len(effects) > 0 {
+		c.backfillStrain("GetStrainEffectsByStrainID", id, func(strain *Strain) { strain.Effects = effectNamesByType(effects) })
+	}
+
+	return effects, err
+}
+
+// SetHandleResourceRequestFunc implements Client by setting the handler
+// on the remote Client.
+func (c *CompositeClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return c.remote.SetHandleResourceRequestFunc(f)
+}
+
+// Capabilities implements CapabilityReporter.  Descriptions are reported
+// when the local store has every description.
+func (c *CompositeClient) Capabilities() Capabilities {
+	local := c.local.Capabilities()
+	remote := ClientCapabilities(c.remote)
+
+	return Capabilities{
+		LiveData:     remote.LiveData,
+		Cached:       remote.Cached,
+		Descriptions: local.Descriptions,
+	}
+}
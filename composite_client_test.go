@@ -0,0 +1,120 @@
+package strainapiclient
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newCompositeTestClients(calls map[string]int) (*FileBackedClient, *DefaultClient) {
+	local := NewSnapshotClient(Snapshot{
+		Effects: []Effect{{Name: "Relaxed", Type: EffectTypePositive}},
+		Flavors: make([]Flavor, 0),
+		Strains: ListAllStrainsResult{"Afpak": {Name: "Afpak", ID: 1, Race: RaceHybrid, Flavors: []Flavor{"Earthy"}}},
+	})
+
+	remote := NewDefaultClient("test-key")
+	remote.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		calls[path[strings.Index(path, "test-key")+len("test-key"):]]++
+
+		switch {
+		case strings.Contains(path, "/strains/data/desc/1"):
+			return []byte("{\"desc\": \"Remote description\"}"), nil
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte("{}"), nil
+		case strings.Contains(path, "/searchdata/flavors"):
+			return []byte("[\"Earthy\", \"Pine\"]"), nil
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"], \"effects\": {}}}"), nil
+		}
+
+		return []byte("[]"), nil
+	})
+
+	return local, remote
+}
+
+func TestCompositeClientLocalFirst(t *testing.T) {
+	calls := make(map[string]int)
+	local, remote := newCompositeTestClients(calls)
+	client := NewCompositeClient(local, remote, false)
+
+	if effects, _ := client.ListAllEffects(); len(effects) != 1 {
+		t.Errorf("Expected the local effect but got %v", effects)
+	}
+
+	if flavors, _ := client.ListAllFlavors(); !reflect.DeepEqual(flavors, []Flavor{"Earthy", "Pine"}) {
+		t.Errorf("Expected the remote flavors for a local miss but got %v", flavors)
+	}
+
+	if description, err := client.GetStrainDescriptionByStrainID(1); err != nil || description != "Remote description" {
+		t.Errorf("Expected the remote description but got '%s' and error %v", description, err)
+	}
+
+	if _, err := client.GetStrainDescriptionByStrainID(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound from the remote but got %v", err)
+	}
+
+	expectedCalls := map[string]int{"/searchdata/flavors": 1, "/strains/data/desc/1": 1, "/strains/data/desc/2": 1}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("Expected calls %v but got %v", expectedCalls, calls)
+	}
+}
+
+func TestCompositeClientBackfill(t *testing.T) {
+	calls := make(map[string]int)
+	local, remote := newCompositeTestClients(calls)
+	client := NewCompositeClient(local, remote, true)
+
+	client.GetStrainDescriptionByStrainID(1)
+	if description, err := client.GetStrainDescriptionByStrainID(1); err != nil || description != "Remote description" {
+		t.Errorf("Expected the backfilled description but got '%s' and error %v", description, err)
+	}
+
+	if calls["/strains/data/desc/1"] != 1 {
+		t.Errorf("Expected the description to be fetched once but got %v", calls)
+	}
+
+	if description, _ := local.GetStrainDescriptionByStrainID(1); description != "Remote description" {
+		t.Errorf("Expected the local store to have the description but got '%s'", description)
+	}
+}
+
+func TestCompositeClientBackfillKeepsDescriptions(t *testing.T) {
+	calls := make(map[string]int)
+	local, remote := newCompositeTestClients(calls)
+	client := NewCompositeClient(local, remote, true)
+	client.SetPolicy(CacheEndpointStrains, CompositeRemoteOnly)
+
+	client.GetStrainDescriptionByStrainID(1)
+	client.ListAllStrains()
+
+	if description, _ := local.GetStrainDescriptionByStrainID(1); description != "Remote description" {
+		t.Errorf("Expected listing strains to keep the backfilled description but got '%s'", description)
+	}
+}
+
+func TestCompositeClientPolicies(t *testing.T) {
+	calls := make(map[string]int)
+	local, remote := newCompositeTestClients(calls)
+	client := NewCompositeClient(local, remote, false)
+
+	if previous := client.SetPolicy(CacheEndpointStrainData, CompositeLocalOnly); previous != CompositeLocalFirst {
+		t.Errorf("Expected the previous policy to be %s but got %s", CompositeLocalFirst, previous)
+	}
+	client.SetPolicy(CacheEndpointEffects, CompositeRemoteOnly)
+
+	if _, err := client.GetStrainDescriptionByStrainID(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the local ErrNotFound but got %v", err)
+	}
+
+	if effects, _ := client.ListAllEffects(); len(effects) != 0 {
+		t.Errorf("Expected the remote's empty effects but got %v", effects)
+	}
+
+	expectedCalls := map[string]int{"/searchdata/effects": 1}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("Expected calls %v but got %v", expectedCalls, calls)
+	}
+}
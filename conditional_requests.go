@@ -0,0 +1,80 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// conditionalRequestTTL is how long validators and bodies are kept for
+// conditional requests; they stay valid for as long as the API says so.
+const conditionalRequestTTL time.Duration = 30 * 24 * time.Hour
+
+// conditionalValidators are the validators and body remembered from the
+// last successful response for a resource.
+type conditionalValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// apply sets the conditional request headers on req.
+func (v conditionalValidators) apply(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// conditionalCacheKey returns the cache key for path: the resource path
+// after the API Key, so the key is never written to the Cache.
+func (c *DefaultClient) conditionalCacheKey(path string) string {
+	return strings.TrimPrefix(path, c.baseURL+"/"+c.apiKey)
+}
+
+// conditionalValidators returns the remembered validators for path, if
+// conditional requests are enabled and there are any.
+func (c *DefaultClient) conditionalValidators(path string) (conditionalValidators, bool) {
+	validators := conditionalValidators{}
+
+	if c.conditionalCache == nil {
+		return validators, false
+	}
+
+	validatorsJSON, found, err := c.conditionalCache.Get(c.conditionalCacheKey(path))
+	if err != nil || !found {
+		return validators, false
+	}
+
+	if err := json.Unmarshal(validatorsJSON, &validators); err != nil {
+		return validators, false
+	}
+
+	return validators, true
+}
+
+// storeConditionalValidators remembers the validators of resp along with
+// body, if conditional requests are enabled and resp has any validators.
+func (c *DefaultClient) storeConditionalValidators(path string, resp *http.Response, body []byte) {
+	if c.conditionalCache == nil {
+		return
+	}
+
+	validators := conditionalValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	}
+
+	if validators.ETag == "" && validators.LastModified == "" {
+		return
+	}
+
+	if validatorsJSON, err := json.Marshal(validators); err == nil {
+		c.conditionalCache.Set(c.conditionalCacheKey(path), validatorsJSON, conditionalRequestTTL)
+	}
+}
@@ -0,0 +1,75 @@
+package strainapiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	fullResponses := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == "\"v1\"" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		fullResponses++
+		w.Header().Set("ETag", "\"v1\"")
+		w.Write([]byte("[\"Earthy\", \"Pine\"]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithConditionalRequests(nil))
+
+	for i := 0; i < 3; i++ {
+		flavors, err := client.ListAllFlavors()
+		if err != nil || len(flavors) != 2 {
+			t.Errorf("Expected 2 flavors on call %d but got %v (error: %v)", i, flavors, err)
+		}
+	}
+
+	if fullResponses != 1 {
+		t.Errorf("Expected 1 full response and the rest 304s but got %d full responses", fullResponses)
+	}
+}
+
+func TestConditionalRequestsKeepAPIKeyOutOfCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", "\"v1\"")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache()
+	client := NewClient("secret-key", WithBaseURL(server.URL), WithConditionalRequests(cache))
+	client.ListAllFlavors()
+
+	if _, found, _ := cache.Get("/searchdata/flavors"); !found {
+		t.Error("Expected the validators to be cached under the resource path")
+	}
+
+	if _, found, _ := cache.Get(server.URL + "/secret-key/searchdata/flavors"); found {
+		t.Error("Expected the validators not to be cached under a path with the API Key")
+	}
+}
+
+func TestConditionalRequestsDisabledByDefault(t *testing.T) {
+	conditionalHeaders := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditionalHeaders++
+		}
+		w.Header().Set("ETag", "\"v1\"")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	client.ListAllFlavors()
+	client.ListAllFlavors()
+
+	if conditionalHeaders != 0 {
+		t.Errorf("Expected no conditional requests but got %d", conditionalHeaders)
+	}
+}
@@ -0,0 +1,74 @@
+package strainapiclient
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CoverageGap describes the data a strain is missing.
+type CoverageGap struct {
+	Name               string       `json:"name"`
+	ID                 int          `json:"id"`
+	MissingDescription bool         `json:"missingDescription"`
+	MissingFlavors     bool         `json:"missingFlavors"`
+	MissingEffectTypes []EffectType `json:"missingEffectTypes"`
+}
+
+// CoverageReport lists the strains in a dataset that lack descriptions,
+// flavors, or any type of effect, for curating a derived catalog.
+type CoverageReport []CoverageGap
+
+// BuildCoverageReport returns a CoverageReport of the strains missing data,
+// sorted by name.  Strains with complete data aren't included.
+func BuildCoverageReport(strains ListAllStrainsResult) CoverageReport {
+	report := make(CoverageReport, 0)
+
+	for _, strain := range strains {
+		gap := CoverageGap{
+			Name:               strain.Name,
+			ID:                 strain.ID,
+			MissingDescription: strings.TrimSpace(strain.Description) == "",
+			MissingFlavors:     len(strain.Flavors) == 0,
+			MissingEffectTypes: make([]EffectType, 0),
+		}
+
+		for _, effectType := range []EffectType{EffectTypePositive, EffectTypeNegative, EffectTypeMedical} {
+			if len(strain.Effects[effectType]) == 0 {
+				gap.MissingEffectTypes = append(gap.MissingEffectTypes, effectType)
+			}
+		}
+
+		if gap.MissingDescription || gap.MissingFlavors || len(gap.MissingEffectTypes) > 0 {
+			report = append(report, gap)
+		}
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Name < report[j].Name })
+
+	return report
+}
+
+// WriteCSV writes the report as CSV with a header row and the columns
+// name, id, missing_description, missing_flavors, and missing_effects
+// (the missing effect types separated by semicolons).
+func (r CoverageReport) WriteCSV(w io.Writer) error {
+	records := make([][]string, 0)
+	for _, gap := range r {
+		effectTypes := make([]string, 0)
+		for _, effectType := range gap.MissingEffectTypes {
+			effectTypes = append(effectTypes, string(effectType))
+		}
+
+		records = append(records, []string{
+			gap.Name,
+			strconv.Itoa(gap.ID),
+			strconv.FormatBool(gap.MissingDescription),
+			strconv.FormatBool(gap.MissingFlavors),
+			strings.Join(effectTypes, csvListSeparator),
+		})
+	}
+
+	return writeCSV(w, []string{"name", "id", "missing_description", "missing_flavors", "missing_effects"}, records)
+}
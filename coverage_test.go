@@ -0,0 +1,44 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuildCoverageReport(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Afpak": {
+			Name: "Afpak", ID: 1, Description: "An indica-dominant hybrid.", Flavors: []Flavor{"Earthy"},
+			Effects: map[EffectType][]string{
+				EffectTypePositive: {"Relaxed"},
+				EffectTypeNegative: {"Dizzy"},
+				EffectTypeMedical:  {"Stress"},
+			},
+		},
+		"Blue Dream": {
+			Name: "Blue Dream", ID: 2, Flavors: []Flavor{"Sweet"},
+			Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}},
+		},
+	}
+
+	report := BuildCoverageReport(strains)
+	if len(report) != 1 || report[0].Name != "Blue Dream" {
+		t.Errorf("Expected only Blue Dream in the report but got %+v", report)
+		return
+	}
+
+	gap := report[0]
+	if !gap.MissingDescription || gap.MissingFlavors || len(gap.MissingEffectTypes) != 2 {
+		t.Errorf("Expected a missing description and 2 missing effect types but got %+v", gap)
+	}
+
+	var buffer bytes.Buffer
+	if err := report.WriteCSV(&buffer); err != nil {
+		t.Errorf("Expected no error writing CSV but got: %s", err)
+	}
+
+	expected := "name,id,missing_description,missing_flavors,missing_effects\nBlue Dream,2,true,false,negative;medical\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
@@ -0,0 +1,109 @@
+package strainapiclient
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// csvListSeparator separates the values of list columns, such as flavors,
+// in CSV exports.
+const csvListSeparator string = ";"
+
+// writeCSV writes a header row and records to w as CSV.
+func writeCSV(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("Problem writing CSV: %w", err)
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("Problem writing CSV: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the strains to w as CSV, sorted by name, with a header
+// row and the columns id, name, race, description, flavors,
+// positive_effects, negative_effects, and medical_effects.  Flavors and
+// effects are separated by semicolons.
+func (r ListAllStrainsResult) WriteCSV(w io.Writer) error {
+	names := make([]string, 0)
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	records := make([][]string, 0)
+	for _, name := range names {
+		strain := r[name]
+
+		flavors := make([]string, 0)
+		for _, flavor := range strain.Flavors {
+			flavors = append(flavors, string(flavor))
+		}
+
+		records = append(records, []string{
+			strconv.Itoa(strain.ID),
+			name,
+			string(strain.Race),
+			strain.Description,
+			strings.Join(flavors, csvListSeparator),
+			strings.Join(strain.Effects[EffectTypePositive], csvListSeparator),
+			strings.Join(strain.Effects[EffectTypeNegative], csvListSeparator),
+			strings.Join(strain.Effects[EffectTypeMedical], csvListSeparator),
+		})
+	}
+
+	header := []string{"id", "name", "race", "description", "flavors", "positive_effects", "negative_effects", "medical_effects"}
+	return writeCSV(w, header, records)
+}
+
+// WriteCSV writes the results to w as CSV with a header row and the
+// columns id, name, race, and description.
+func (r SearchStrainsByNameResults) WriteCSV(w io.Writer) error {
+	records := make([][]string, 0)
+	for _, result := range r {
+		records = append(records, []string{strconv.Itoa(result.ID), result.Name, string(result.Race), result.Description})
+	}
+
+	return writeCSV(w, []string{"id", "name", "race", "description"}, records)
+}
+
+// WriteCSV writes the results to w as CSV with a header row and the
+// columns id, name, and race.
+func (r SearchStrainsByRaceResults) WriteCSV(w io.Writer) error {
+	records := make([][]string, 0)
+	for _, result := range r {
+		records = append(records, []string{strconv.Itoa(result.ID), result.Name, string(result.Race)})
+	}
+
+	return writeCSV(w, []string{"id", "name", "race"}, records)
+}
+
+// WriteCSV writes the results to w as CSV with a header row and the
+// columns id, name, race, and effect.
+func (r SearchStrainsByEffectNameResults) WriteCSV(w io.Writer) error {
+	records := make([][]string, 0)
+	for _, result := range r {
+		records = append(records, []string{strconv.Itoa(result.ID), result.Name, string(result.Race), result.EffectName})
+	}
+
+	return writeCSV(w, []string{"id", "name", "race", "effect"}, records)
+}
+
+// WriteCSV writes the results to w as CSV with a header row and the
+// columns id, name, race, and flavor.
+func (r SearchStrainsByFlavorResults) WriteCSV(w io.Writer) error {
+	records := make([][]string, 0)
+	for _, result := range r {
+		records = append(records, []string{strconv.Itoa(result.ID), result.Name, string(result.Race), string(result.Flavor)})
+	}
+
+	return writeCSV(w, []string{"id", "name", "race", "flavor"}, records)
+}
@@ -0,0 +1,40 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestListAllStrainsResultWriteCSV(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Blue Dream": {ID: 2, Race: RaceHybrid, Description: "Sweet, berry aroma.", Flavors: []Flavor{"Sweet", "Berry"},
+			Effects: map[EffectType][]string{EffectTypePositive: {"Happy", "Relaxed"}, EffectTypeMedical: {"Stress"}}},
+		"Afpak": {ID: 1, Race: RaceHybrid},
+	}
+
+	var buffer bytes.Buffer
+	if err := strains.WriteCSV(&buffer); err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	expected := "id,name,race,description,flavors,positive_effects,negative_effects,medical_effects\n" +
+		"1,Afpak,hybrid,,,,,\n" +
+		"2,Blue Dream,hybrid,\"Sweet, berry aroma.\",Sweet;Berry,Happy;Relaxed,,Stress\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
+
+func TestSearchResultsWriteCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	SearchStrainsByFlavorResults{{ID: 4, Name: "Sour Diesel", Race: RaceSativa, Flavor: "Diesel"}}.WriteCSV(&buffer)
+	if expected := "id,name,race,flavor\n4,Sour Diesel,sativa,Diesel\n"; buffer.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+
+	buffer.Reset()
+	SearchStrainsByEffectNameResults{{ID: 3, Name: "Northern Lights", Race: RaceIndica, EffectName: "Sleepy"}}.WriteCSV(&buffer)
+	if expected := "id,name,race,effect\n3,Northern Lights,indica,Sleepy\n"; buffer.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
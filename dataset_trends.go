@@ -0,0 +1,193 @@
+package strainapiclient
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DatasetStats summarizes a dataset at a point in time: how many strains
+// it has, how many of each race, and the fraction of strains with each
+// effect.
+type DatasetStats struct {
+	Time             time.Time          `json:"time"`
+	Strains          int                `json:"strains"`
+	ByRace           map[Race]int       `json:"byRace"`
+	EffectPrevalence map[string]float64 `json:"effectPrevalence"`
+}
+
+// ComputeDatasetStats returns the DatasetStats of snapshot, taken at at.
+func ComputeDatasetStats(snapshot Snapshot, at time.Time) DatasetStats {
+	stats := DatasetStats{Time: at, Strains: len(snapshot.Strains), ByRace: make(map[Race]int), EffectPrevalence: make(map[string]float64)}
+
+	for _, strain := range snapshot.Strains {
+		stats.ByRace[strain.Race]++
+
+		seen := make(map[string]bool)
+		for _, names := range strain.Effects {
+			for _, name := range names {
+				if !seen[name] {
+					seen[name] = true
+					stats.EffectPrevalence[name]++
+				}
+			}
+		}
+	}
+
+	for name, count := range stats.EffectPrevalence {
+		stats.EffectPrevalence[name] = count / float64(stats.Strains)
+	}
+
+	return stats
+}
+
+// DatasetTrend is how a dataset changed between two DatasetStats: the
+// change in the number of strains, in the count of each race, and in the
+// prevalence of each effect (only races and effects that changed are
+// included).
+type DatasetTrend struct {
+	From                   DatasetStats       `json:"from"`
+	To                     DatasetStats       `json:"to"`
+	Samples                int                `json:"samples"`
+	StrainsChange          int                `json:"strainsChange"`
+	RaceChanges            map[Race]int       `json:"raceChanges"`
+	EffectPrevalenceChange map[string]float64 `json:"effectPrevalenceChange"`
+}
+
+// DatasetStatsLog persists DatasetStats to a file, one JSON object a line,
+// so trends can be reported across restarts.  Attach one to a Syncer with
+// SetStatsLog to record the stats of every sync.  It is safe for concurrent
+// use.
+type DatasetStatsLog struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewDatasetStatsLog creates a new DatasetStatsLog appending to the file at
+// path, which is created by the first Append if it doesn't exist.
+func NewDatasetStatsLog(path string) *DatasetStatsLog {
+	return &DatasetStatsLog{path: path}
+}
+
+// Append adds stats to the end of the log.
+func (l *DatasetStatsLog) Append(stats DatasetStats) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	line, marshallErr := json.Marshal(stats)
+	if marshallErr != nil {
+		return fmt.Errorf("Problem encoding dataset stats: %w", marshallErr)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Problem opening dataset stats log %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("Problem writing dataset stats log %s: %w", l.path, err)
+	}
+
+	return nil
+}
+
+// Load returns every DatasetStats in the log, oldest first.  A log that
+// doesn't exist yet is empty.
+func (l *DatasetStatsLog) Load() ([]DatasetStats, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	all := make([]DatasetStats, 0)
+
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem opening dataset stats log %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		stats := DatasetStats{}
+		if marshallErr := json.Unmarshal(scanner.Bytes(), &stats); marshallErr != nil {
+			return nil, fmt.Errorf("Problem parsing dataset stats log %s: %w", l.path, marshallErr)
+		}
+		all = append(all, stats)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Problem reading dataset stats log %s: %w", l.path, err)
+	}
+
+	return all, nil
+}
+
+// TrendReport returns how the dataset changed over the last window: from
+// the oldest stats in the window to the newest in the log.  An error
+// wrapping ErrNotFound is returned when the log has no stats in the
+// window.
+func (l *DatasetStatsLog) TrendReport(window time.Duration) (DatasetTrend, error) {
+	all, err := l.Load()
+	if err != nil {
+		return DatasetTrend{}, err
+	}
+
+	if len(all) == 0 {
+		return DatasetTrend{}, fmt.Errorf("No dataset stats have been recorded: %w", ErrNotFound)
+	}
+
+	to := all[len(all)-1]
+	start := to.Time.Add(-window)
+
+	inWindow := make([]DatasetStats, 0)
+	for _, stats := range all {
+		if !stats.Time.Before(start) {
+			inWindow = append(inWindow, stats)
+		}
+	}
+
+	return compareDatasetStats(inWindow[0], to, len(inWindow)), nil
+}
+
+// compareDatasetStats returns the DatasetTrend from from to to.
+func compareDatasetStats(from DatasetStats, to DatasetStats, samples int) DatasetTrend {
+	trend := DatasetTrend{
+		From:                   from,
+		To:                     to,
+		Samples:                samples,
+		StrainsChange:          to.Strains - from.Strains,
+		RaceChanges:            make(map[Race]int),
+		EffectPrevalenceChange: make(map[string]float64),
+	}
+
+	for race, count := range to.ByRace {
+		trend.RaceChanges[race] = count
+	}
+	for race, count := range from.ByRace {
+		trend.RaceChanges[race] -= count
+		if trend.RaceChanges[race] == 0 {
+			delete(trend.RaceChanges, race)
+		}
+	}
+
+	for name, prevalence := range to.EffectPrevalence {
+		trend.EffectPrevalenceChange[name] = prevalence
+	}
+	for name, prevalence := range from.EffectPrevalence {
+		trend.EffectPrevalenceChange[name] -= prevalence
+	}
+	for name, change := range trend.EffectPrevalenceChange {
+		if change == 0 {
+			delete(trend.EffectPrevalenceChange, name)
+		}
+	}
+
+	return trend
+}
@@ -0,0 +1,81 @@
+package strainapiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestComputeDatasetStats(t *testing.T) {
+	stats := ComputeDatasetStats(Snapshot{Strains: ListAllStrainsResult{
+		"Afpak":      {ID: 1, Race: RaceHybrid, Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed", "Happy"}}},
+		"Blue Dream": {ID: 2, Race: RaceHybrid, Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}},
+	}}, time.Now())
+
+	if stats.Strains != 2 || stats.ByRace[RaceHybrid] != 2 {
+		t.Errorf("Expected 2 hybrid strains but got %+v", stats)
+	}
+
+	if stats.EffectPrevalence["Happy"] != 1 || stats.EffectPrevalence["Relaxed"] != 0.5 {
+		t.Errorf("Expected Happy in every strain and Relaxed in half but got %v", stats.EffectPrevalence)
+	}
+}
+
+func TestDatasetStatsLogTrendReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := NewDatasetStatsLog(filepath.Join(dir, "stats.jsonl"))
+	if _, err := log.TrendReport(time.Hour); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an empty log but got %v", err)
+	}
+
+	now := time.Now()
+	log.Append(DatasetStats{Time: now.Add(-48 * time.Hour), Strains: 1, ByRace: map[Race]int{RaceIndica: 1}})
+	log.Append(DatasetStats{Time: now.Add(-2 * time.Hour), Strains: 2, ByRace: map[Race]int{RaceIndica: 2},
+		EffectPrevalence: map[string]float64{"Happy": 0.5}})
+	log.Append(DatasetStats{Time: now, Strains: 4, ByRace: map[Race]int{RaceIndica: 2, RaceSativa: 2},
+		EffectPrevalence: map[string]float64{"Happy": 0.75}})
+
+	trend, err := log.TrendReport(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if trend.Samples != 2 || trend.StrainsChange != 2 {
+		t.Errorf("Expected 2 samples and 2 more strains but got %+v", trend)
+	}
+
+	if len(trend.RaceChanges) != 1 || trend.RaceChanges[RaceSativa] != 2 {
+		t.Errorf("Expected only 2 more sativa strains but got %v", trend.RaceChanges)
+	}
+
+	if trend.EffectPrevalenceChange["Happy"] != 0.25 {
+		t.Errorf("Expected Happy to be 0.25 more prevalent but got %v", trend.EffectPrevalenceChange)
+	}
+}
+
+func TestSyncerRecordsDatasetStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := NewDatasetStatsLog(filepath.Join(dir, "stats.jsonl"))
+	syncer := NewSyncer(NewSnapshotClient(newFakeSnapshot()), time.Hour)
+	syncer.SetStatsLog(log)
+	syncer.Sync()
+	syncer.Sync()
+
+	all, err := log.Load()
+	if err != nil || len(all) != 2 || all[1].Strains != len(newFakeSnapshot().Strains) {
+		t.Errorf("Expected stats for both syncs but got %v (error: %v)", all, err)
+	}
+}
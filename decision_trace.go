@@ -0,0 +1,111 @@
+package strainapiclient
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// DecisionKind is a decision a Client made about where to answer a call
+// from.
+type DecisionKind string
+
+// The valid values of DecisionKind
+const (
+	// DecisionCacheHit means a CachingClient answered from its cache.
+	DecisionCacheHit DecisionKind = "cache-hit"
+	// DecisionCacheMiss means a CachingClient had no fresh cached result
+	// and fetched one.
+	DecisionCacheMiss = "cache-miss"
+	// DecisionNegativeCacheHit means a CachingClient answered with a cached
+	// ErrNotFound.
+	DecisionNegativeCacheHit = "negative-cache-hit"
+	// DecisionSharedFetch means a CachingClient waited for another caller's
+	// fetch of the same key instead of fetching it again.
+	DecisionSharedFetch = "shared-fetch"
+	// DecisionLiveFallback means a CompositeClient's local store had no
+	// result, so the API was called.
+	DecisionLiveFallback = "live-fallback"
+	// DecisionBackfill means a CompositeClient saved data from the API to
+	// its local store.
+	DecisionBackfill = "backfill"
+	// DecisionFallback means a FallbackClient's primary Client was
+	// unavailable, so its fallback Client was called.
+	DecisionFallback = "fallback"
+)
+
+// DecisionEvent describes one decision, for tracing why a call was slow or
+// returned old data.  Source is the type of Client that made the decision,
+// Key identifies the call (a cache key or method name), and Reason explains
+// the decision.
+type DecisionEvent struct {
+	Kind   DecisionKind `json:"kind"`
+	Source string       `json:"source"`
+	Key    string       `json:"key"`
+	Reason string       `json:"reason,omitempty"`
+	Time   time.Time    `json:"time"`
+}
+
+// DecisionHook is called with each DecisionEvent.  It is called on the
+// goroutine making the call, so it should return quickly.
+type DecisionHook func(event DecisionEvent)
+
+// NewDecisionLogger returns a DecisionHook that logs each event to logger.
+func NewDecisionLogger(logger *log.Logger) DecisionHook {
+	return func(event DecisionEvent) {
+		if event.Reason == "" {
+			logger.Printf("%s %s %s", event.Source, event.Kind, event.Key)
+			return
+		}
+		logger.Printf("%s %s %s: %s", event.Source, event.Kind, event.Key, event.Reason)
+	}
+}
+
+// decisionHooks holds the DecisionHook of a Client.  Its zero value has no
+// hook.
+type decisionHooks struct {
+	mutex sync.Mutex
+	hook  DecisionHook
+}
+
+// set sets the hook and returns the previous one.
+func (d *decisionHooks) set(hook DecisionHook) DecisionHook {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	current := d.hook
+	d.hook = hook
+	return current
+}
+
+// emit calls the hook, if any, with a new event.
+func (d *decisionHooks) emit(kind DecisionKind, source string, key string, reason string) {
+	d.mutex.Lock()
+	hook := d.hook
+	d.mutex.Unlock()
+
+	if hook != nil {
+		hook(DecisionEvent{Kind: kind, Source: source, Key: key, Reason: reason, Time: time.Now()})
+	}
+}
+
+// SetDecisionHook sets the DecisionHook called for each cache hit, miss,
+// negative cache hit, and shared fetch, and returns the previous one.  Pass
+// nil to stop tracing.
+func (c *CachingClient) SetDecisionHook(hook DecisionHook) DecisionHook {
+	return c.decisions.set(hook)
+}
+
+// SetDecisionHook sets the DecisionHook called whenever the local store
+// has no result and the API is called, and whenever data is backfilled,
+// and returns the previous one.  Pass nil to stop tracing.
+func (c *CompositeClient) SetDecisionHook(hook DecisionHook) DecisionHook {
+	return c.decisions.set(hook)
+}
+
+// SetDecisionHook sets the DecisionHook called whenever the primary Client
+// is unavailable and the fallback is called, and returns the previous one.
+// Pass nil to stop tracing.
+func (c *FallbackClient) SetDecisionHook(hook DecisionHook) DecisionHook {
+	return c.decisions.set(hook)
+}
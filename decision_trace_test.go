@@ -0,0 +1,103 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// decisionRecorder collects DecisionEvents for tests.
+type decisionRecorder struct {
+	mutex  sync.Mutex
+	events []DecisionEvent
+}
+
+func (r *decisionRecorder) record(event DecisionEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *decisionRecorder) kinds() []DecisionKind {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	kinds := make([]DecisionKind, 0)
+	for _, event := range r.events {
+		kinds = append(kinds, event.Kind)
+	}
+	return kinds
+}
+
+func TestCachingClientDecisionHook(t *testing.T) {
+	recorder := &decisionRecorder{}
+	client := NewCachingClient(newCountingTestClient(make(map[string]int)), nil)
+	client.SetDecisionHook(recorder.record)
+
+	client.ListAllStrains()
+	client.ListAllStrains()
+
+	kinds := recorder.kinds()
+	if len(kinds) != 2 || kinds[0] != DecisionCacheMiss || kinds[1] != DecisionCacheHit {
+		t.Errorf("Expected a miss then a hit but got %v", kinds)
+	}
+
+	if event := recorder.events[0]; event.Source != "CachingClient" || event.Key != "strains" || event.Reason == "" {
+		t.Errorf("Expected a traced miss for the strains key with a reason but got %+v", event)
+	}
+
+	if previous := client.SetDecisionHook(nil); previous == nil {
+		t.Error("Expected the previous hook to be returned but got nil")
+	}
+}
+
+func TestCompositeClientDecisionHook(t *testing.T) {
+	recorder := &decisionRecorder{}
+	local, remote := newCompositeTestClients(make(map[string]int))
+	client := NewCompositeClient(local, remote, true)
+	client.SetDecisionHook(recorder.record)
+
+	client.ListAllEffects()
+	client.GetStrainDescriptionByStrainID(1)
+
+	kinds := recorder.kinds()
+	if len(kinds) != 2 || kinds[0] != DecisionLiveFallback || kinds[1] != DecisionBackfill {
+		t.Errorf("Expected a live fallback then a backfill but got %v", kinds)
+	}
+
+	if event := recorder.events[0]; event.Key != "GetStrainDescriptionByStrainID" || !strings.Contains(event.Reason, "no description") {
+		t.Errorf("Expected the local ErrNotFound as the reason but got %+v", event)
+	}
+}
+
+func TestFallbackClientDecisionHook(t *testing.T) {
+	recorder := &decisionRecorder{}
+	primary := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	primary.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 503}
+	})
+	client := NewFallbackClient(primary, NewSnapshotClient(newFakeSnapshot()))
+	client.SetDecisionHook(recorder.record)
+
+	client.ListAllFlavors()
+
+	if len(recorder.events) != 1 || recorder.events[0].Kind != DecisionFallback || recorder.events[0].Key != "ListAllFlavors" {
+		t.Errorf("Expected a fallback for ListAllFlavors but got %+v", recorder.events)
+	}
+}
+
+func TestNewDecisionLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	hook := NewDecisionLogger(log.New(&buffer, "", 0))
+
+	hook(DecisionEvent{Kind: DecisionCacheHit, Source: "CachingClient", Key: "strains"})
+	hook(DecisionEvent{Kind: DecisionFallback, Source: "FallbackClient", Key: "ListAllFlavors", Reason: "Server error"})
+
+	expected := "CachingClient cache-hit strains\nFallbackClient fallback ListAllFlavors: Server error\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected log %q but got %q", expected, buffer.String())
+	}
+}
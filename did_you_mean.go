@@ -0,0 +1,68 @@
+package strainapiclient
+
+import "fmt"
+
+// NameSearchResult is the result of SearchStrainsByNameWithSuggestions: the
+// strains the API found and, when it found none, the names of strains the
+// caller may have meant.
+type NameSearchResult struct {
+	Results     SearchStrainsByNameResults `json:"results"`
+	Suggestions []string                   `json:"suggestions"`
+}
+
+// SearchStrainsByNameWithSuggestions calls client.SearchStrainsByName and,
+// if it finds nothing, suggests up to maxSuggestions "did you mean" names
+// from client.ListAllStrains: names within a few edits of name first (see
+// SearchStrainsByNameFuzzy), then names that sound like it (see
+// RankStrainsByNamePhonetic).  Wrap client in a CachingClient so the list
+// of names isn't fetched for every empty search.
+func SearchStrainsByNameWithSuggestions(client Client, name string, maxSuggestions int) (NameSearchResult, error) {
+	result := NameSearchResult{Suggestions: make([]string, 0)}
+
+	var err error
+	if result.Results, err = client.SearchStrainsByName(name); err != nil || len(result.Results) > 0 {
+		return result, err
+	}
+
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return result, fmt.Errorf("Problem listing strains for suggestions: %w", err)
+	}
+
+	result.Suggestions = SuggestStrainNamesFor(strains, name, maxSuggestions)
+	return result, nil
+}
+
+// SuggestStrainNamesFor returns up to maxSuggestions names from strains that
+// name may have been a misspelling of, closest first.  Names are suggested
+// when they are within DefaultTypoTolerance of name, or when at least half
+// of their words sound like words of name.
+func SuggestStrainNamesFor(strains ListAllStrainsResult, name string, maxSuggestions int) []string {
+	return SuggestStrainNamesWithTolerance(strains, name, maxSuggestions, DefaultTypoTolerance)
+}
+
+// SuggestStrainNamesWithTolerance is SuggestStrainNamesFor with the edits
+// allowed between name and a suggested name set by tolerance.
+func SuggestStrainNamesWithTolerance(strains ListAllStrainsResult, name string, maxSuggestions int, tolerance TypoTolerance) []string {
+	suggestions := make([]string, 0)
+	suggested := make(map[string]bool)
+
+	add := func(strainName string) {
+		if !suggested[strainName] && len(suggestions) < maxSuggestions {
+			suggested[strainName] = true
+			suggestions = append(suggestions, strainName)
+		}
+	}
+
+	for _, strain := range strains.SearchStrainsByNameFuzzyWithTolerance(name, tolerance) {
+		add(strain.Name)
+	}
+
+	for _, match := range strains.RankStrainsByNamePhonetic(name) {
+		if match.Score >= 0.5 {
+			add(match.Strain.Name)
+		}
+	}
+
+	return suggestions
+}
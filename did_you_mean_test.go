@@ -0,0 +1,62 @@
+package strainapiclient
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuggestStrainNamesWithTolerance(t *testing.T) {
+	strains := ListAllStrainsResult{"Afpak": {ID: 1}}
+
+	if suggestions := SuggestStrainNamesWithTolerance(strains, "Zfpka", 3, TypoTolerance{MinDistance: 1}); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions within 1 edit but got %v", suggestions)
+	}
+
+	if suggestions := SuggestStrainNamesWithTolerance(strains, "Zfpka", 3, TypoTolerance{Relative: 0.6}); !reflect.DeepEqual(suggestions, []string{"Afpak"}) {
+		t.Errorf("Expected Afpak but got %v", suggestions)
+	}
+}
+
+func TestSuggestStrainNamesFor(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Blue Dream":   {ID: 1},
+		"Gorilla Glue": {ID: 2},
+		"Sour Diesel":  {ID: 3},
+	}
+
+	if suggestions := SuggestStrainNamesFor(strains, "Blu Dreem", 3); !reflect.DeepEqual(suggestions, []string{"Blue Dream"}) {
+		t.Errorf("Expected Blue Dream but got %v", suggestions)
+	}
+
+	if suggestions := SuggestStrainNamesFor(strains, "Gorrila Gloo", 3); !reflect.DeepEqual(suggestions, []string{"Gorilla Glue"}) {
+		t.Errorf("Expected Gorilla Glue but got %v", suggestions)
+	}
+
+	if suggestions := SuggestStrainNamesFor(strains, "Pineapple Express", 3); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions but got %v", suggestions)
+	}
+}
+
+func TestSearchStrainsByNameWithSuggestions(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.HasSuffix(path, "/strains/search/name/Afpak"):
+			return []byte("[{\"id\": 1, \"name\": \"Afpak\", \"race\": \"hybrid\"}]"), nil
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\"}}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	result, err := SearchStrainsByNameWithSuggestions(client, "Afpak", 3)
+	if err != nil || len(result.Results) != 1 || len(result.Suggestions) != 0 {
+		t.Errorf("Expected 1 result and no suggestions but got %v and error %v", result, err)
+	}
+
+	result, err = SearchStrainsByNameWithSuggestions(client, "Afpack", 3)
+	if err != nil || len(result.Results) != 0 || !reflect.DeepEqual(result.Suggestions, []string{"Afpak"}) {
+		t.Errorf("Expected no results and the suggestion Afpak but got %v and error %v", result, err)
+	}
+}
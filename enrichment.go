@@ -0,0 +1,135 @@
+package strainapiclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// EnrichmentProvider supplies per-strain supplemental data, such as THC%,
+// terpenes, or pricing, that is merged into Strain.Extensions.
+type EnrichmentProvider interface {
+	// Enrich returns the extension values for strain, or nil if the
+	// provider has nothing for it.
+	Enrich(strain Strain) (map[string]interface{}, error)
+}
+
+// EnrichStrain merges the values from each provider into strain.Extensions.
+// Providers are applied in order, so a later provider's value for a key
+// replaces an earlier one; keys a provider doesn't return are left alone.
+func EnrichStrain(strain *Strain, providers ...EnrichmentProvider) error {
+	for _, provider := range providers {
+		values, err := provider.Enrich(*strain)
+		if err != nil {
+			return fmt.Errorf("Problem enriching strain %s: %w", strain.Name, err)
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		if strain.Extensions == nil {
+			strain.Extensions = make(map[string]interface{})
+		}
+
+		for key, value := range values {
+			strain.Extensions[key] = value
+		}
+	}
+
+	return nil
+}
+
+// Enrich merges the values from each provider into the Extensions of every
+// strain using EnrichStrain.
+func (r ListAllStrainsResult) Enrich(providers ...EnrichmentProvider) error {
+	for name, strain := range r {
+		if err := EnrichStrain(&strain, providers...); err != nil {
+			return err
+		}
+		// Have to assign it back to the map to make it stick
+		r[name] = strain
+	}
+
+	return nil
+}
+
+// FileEnrichmentProvider is an EnrichmentProvider backed by data loaded
+// from a JSON or CSV file, keyed by strain name (case-insensitive).
+type FileEnrichmentProvider struct {
+	valuesByStrain map[string]map[string]interface{}
+}
+
+// NewJSONEnrichmentProvider reads a FileEnrichmentProvider from a JSON
+// object keyed by strain name, e.g. {"Afpak": {"thc": 18.5, "price": 45}}.
+func NewJSONEnrichmentProvider(r io.Reader) (*FileEnrichmentProvider, error) {
+	data := make(map[string]map[string]interface{})
+
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("Problem parsing enrichment JSON: %w", err)
+	}
+
+	provider := &FileEnrichmentProvider{valuesByStrain: make(map[string]map[string]interface{})}
+	for name, values := range data {
+		provider.valuesByStrain[strings.ToLower(name)] = values
+	}
+
+	return provider, nil
+}
+
+// NewCSVEnrichmentProvider reads a FileEnrichmentProvider from CSV with a
+// header row.  The "strain" column names the strain and every other column
+// becomes an extension key; values that parse as numbers are stored as
+// float64 and empty values are skipped.
+func NewCSVEnrichmentProvider(r io.Reader) (*FileEnrichmentProvider, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Problem reading enrichment CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("Enrichment CSV has no header row")
+	}
+
+	strainColumn := -1
+	for index, name := range records[0] {
+		if strings.ToLower(strings.TrimSpace(name)) == "strain" {
+			strainColumn = index
+		}
+	}
+
+	if strainColumn < 0 {
+		return nil, fmt.Errorf("Enrichment CSV is missing the 'strain' column")
+	}
+
+	provider := &FileEnrichmentProvider{valuesByStrain: make(map[string]map[string]interface{})}
+
+	for _, record := range records[1:] {
+		values := make(map[string]interface{})
+
+		for index, column := range records[0] {
+			value := strings.TrimSpace(record[index])
+			if index == strainColumn || value == "" {
+				continue
+			}
+
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				values[strings.TrimSpace(column)] = number
+			} else {
+				values[strings.TrimSpace(column)] = value
+			}
+		}
+
+		provider.valuesByStrain[strings.ToLower(strings.TrimSpace(record[strainColumn]))] = values
+	}
+
+	return provider, nil
+}
+
+// Enrich implements EnrichmentProvider.
+func (p *FileEnrichmentProvider) Enrich(strain Strain) (map[string]interface{}, error) {
+	return p.valuesByStrain[strings.ToLower(strain.Name)], nil
+}
@@ -0,0 +1,43 @@
+package strainapiclient
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnrichMergesProvidersInOrder(t *testing.T) {
+	lab, err := NewCSVEnrichmentProvider(strings.NewReader("strain,thc,terpenes\nAfpak,18.5,Myrcene\nBlue Dream,21,\n"))
+	if err != nil {
+		t.Errorf("Expected no error loading CSV but got: %s", err)
+		return
+	}
+
+	retailer, err := NewJSONEnrichmentProvider(strings.NewReader(`{"afpak": {"price": 45, "thc": 19}}`))
+	if err != nil {
+		t.Errorf("Expected no error loading JSON but got: %s", err)
+		return
+	}
+
+	strains := ListAllStrainsResult{
+		"Afpak":      {Name: "Afpak"},
+		"Blue Dream": {Name: "Blue Dream"},
+		"Unknown":    {Name: "Unknown"},
+	}
+
+	if err := strains.Enrich(lab, retailer); err != nil {
+		t.Errorf("Expected no error enriching but got: %s", err)
+	}
+
+	afpak := strains["Afpak"].Extensions
+	if afpak["thc"] != float64(19) || afpak["price"] != float64(45) || afpak["terpenes"] != "Myrcene" {
+		t.Errorf("Expected the retailer THC to override the lab value; got %v", afpak)
+	}
+
+	if _, found := strains["Blue Dream"].Extensions["terpenes"]; found || strains["Blue Dream"].Extensions["thc"] != float64(21) {
+		t.Errorf("Expected Blue Dream to have only a THC value; got %v", strains["Blue Dream"].Extensions)
+	}
+
+	if strains["Unknown"].Extensions != nil {
+		t.Errorf("Expected no extensions for an unknown strain; got %v", strains["Unknown"].Extensions)
+	}
+}
@@ -0,0 +1,151 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Code is a stable, machine-readable error code.  Codes never change between
+// versions, so logs and dashboards can aggregate on them.
+type Code string
+
+// Error codes returned by ErrorCode and the Code method of the package's
+// errors.
+const (
+	CodeNotFound        Code = "strainapi/not_found"
+	CodeUnauthorized    Code = "strainapi/unauthorized"
+	CodeRateLimited     Code = "strainapi/rate_limited"
+	CodeServerError     Code = "strainapi/server_error"
+	CodeAPIError        Code = "strainapi/api_error"
+	CodeCircuitOpen     Code = "strainapi/circuit_open"
+	CodeCassetteMiss    Code = "strainapi/cassette_miss"
+	CodeSnapshotCorrupt Code = "strainapi/snapshot_corrupt"
+	CodeInvalidOptions  Code = "strainapi/invalid_options"
+	CodeIDConflict      Code = "strainapi/id_conflict"
+	CodeInvalidQuery    Code = "strainapi/invalid_query"
+	CodeTimeout         Code = "strainapi/timeout"
+	CodeCanceled        Code = "strainapi/canceled"
+	CodeUnknown         Code = "strainapi/unknown"
+)
+
+// codedError is an error with a stable code.
+type codedError struct {
+	code    Code
+	message string
+}
+
+func newCodedError(code Code, message string) error {
+	return &codedError{code: code, message: message}
+}
+
+func (e *codedError) Error() string {
+	return e.message
+}
+
+// Code returns the stable code of the error.
+func (e *codedError) Code() Code {
+	return e.code
+}
+
+// Sentinel errors returned (wrapped) by the client so callers can use
+// errors.Is instead of matching on error text.
+var (
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = newCodedError(CodeNotFound, "Not found")
+	// ErrUnauthorized is returned when the API Key is missing or rejected.
+	ErrUnauthorized = newCodedError(CodeUnauthorized, "Unauthorized")
+	// ErrRateLimited is returned when the API is throttling requests.
+	ErrRateLimited = newCodedError(CodeRateLimited, "Rate limited")
+	// ErrServerError is returned when the API fails with a 5xx status.
+	ErrServerError = newCodedError(CodeServerError, "Server error")
+)
+
+// ErrorCode returns the stable code for err: the Code of the first error in
+// its chain that has one, CodeCanceled or CodeTimeout for cancelled or
+// timed out calls, or CodeUnknown.  It returns "" for a nil error.
+func ErrorCode(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var coded interface{ Code() Code }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return CodeTimeout
+	}
+
+	return CodeUnknown
+}
+
+// APIError is returned by the default request handler when the API
+// responds with a status other than 200 OK.  It unwraps to the sentinel
+// error matching its status code, if there is one.  RetryAfter is set
+// from the Retry-After header, if the response had one.
+type APIError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Status: %d - %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the sentinel error for the status code of the APIError.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServerError
+	}
+
+	return nil
+}
+
+// Code returns the stable code for the status code of the APIError.
+func (e *APIError) Code() Code {
+	if sentinel := e.Unwrap(); sentinel != nil {
+		return ErrorCode(sentinel)
+	}
+
+	return CodeAPIError
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,83 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSentinelErrorsFromStatusCodes(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusBadGateway, ErrServerError},
+	}
+
+	for _, test := range tests {
+		statusCode := test.statusCode
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+			w.Write([]byte("nope"))
+		}))
+
+		client := NewClient("test-key", WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy))
+
+		if _, err := client.SearchStrainsByName("Af"); !errors.Is(err, test.expected) {
+			t.Errorf("Expected status %d from search to be %v but got %v", statusCode, test.expected, err)
+		}
+
+		if _, err := client.GetStrainFlavorsByStrainID(1); !errors.Is(err, test.expected) {
+			t.Errorf("Expected status %d from flavors to be %v but got %v", statusCode, test.expected, err)
+		}
+
+		var apiErr *APIError
+		if _, err := client.ListAllEffects(); !errors.As(err, &apiErr) || apiErr.StatusCode != statusCode || apiErr.Body != "nope" {
+			t.Errorf("Expected an *APIError with status %d but got %v", statusCode, err)
+		}
+
+		server.Close()
+	}
+}
+
+func TestMissingDescriptionIsNotFound(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return []byte("{}"), nil
+	})
+
+	if _, err := client.GetStrainDescriptionByStrainID(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Code
+	}{
+		{nil, ""},
+		{&APIError{StatusCode: http.StatusNotFound}, CodeNotFound},
+		{fmt.Errorf("Problem: %w", &APIError{StatusCode: http.StatusTooManyRequests}), CodeRateLimited},
+		{&APIError{StatusCode: http.StatusBadRequest}, CodeAPIError},
+		{fmt.Errorf("Missing: %w", ErrNotFound), CodeNotFound},
+		{ErrCircuitOpen, CodeCircuitOpen},
+		{fmt.Errorf("Problem: %w", context.DeadlineExceeded), CodeTimeout},
+		{context.Canceled, CodeCanceled},
+		{errors.New("Something else"), CodeUnknown},
+	}
+
+	for _, test := range tests {
+		if code := ErrorCode(test.err); code != test.expected {
+			t.Errorf("Expected code '%s' for %v but got '%s'", test.expected, test.err, code)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Pieces used to build realistic-looking synthetic strain names.
+var fakeStrainNamePrefixes = []string{
+	"Purple", "Lemon", "Northern", "Blue", "Golden", "Sour", "Cherry", "Granddaddy",
+	"Super", "Green", "Tangerine", "Ghost", "Strawberry", "Jack", "Bubba", "Pineapple",
+}
+
+var fakeStrainNameSuffixes = []string{
+	"Kush", "Haze", "Dream", "OG", "Diesel", "Cookies", "Express", "Glue",
+	"Lights", "Widow", "Cheese", "Skunk", "Punch", "Train", "Mist", "Fire",
+}
+
+// Flavors are grouped into families so a strain's flavors go together.
+var fakeFlavorFamilies = [][]Flavor{
+	{"Citrus", "Lemon", "Lime", "Orange", "Tangy"},
+	{"Earthy", "Woody", "Pine", "Pungent", "Diesel", "Skunk"},
+	{"Sweet", "Berry", "Grape", "Blueberry", "Vanilla", "Strawberry"},
+	{"Spicy/Herbal", "Pepper", "Sage", "Mint", "Menthol"},
+	{"Tropical", "Mango", "Pineapple", "Apricot", "Tree Fruit"},
+}
+
+// Positive effects that lean towards indica or sativa strains.
+var fakeIndicaPositiveEffects = []string{"Relaxed", "Sleepy", "Hungry", "Happy", "Tingly", "Euphoric"}
+var fakeSativaPositiveEffects = []string{"Energetic", "Uplifted", "Focused", "Creative", "Talkative", "Happy", "Giggly"}
+
+var fakeNegativeEffects = []string{"Dizzy", "Dry Mouth", "Paranoid", "Dry Eyes", "Anxious"}
+
+var fakeMedicalEffects = []string{
+	"Depression", "Insomnia", "Pain", "Stress", "Lack of Appetite",
+	"Nausea", "Headache", "Fatigue", "Inflammation", "Muscle Spasms",
+}
+
+// FakeDatasetGenerator produces realistic synthetic strains for load tests
+// and demos that shouldn't use real upstream data.  Generators created with
+// the same seed produce the same strains in the same order.
+type FakeDatasetGenerator struct {
+	rand   *rand.Rand
+	nextID int
+}
+
+// NewFakeDatasetGenerator creates a new FakeDatasetGenerator seeded with seed.
+func NewFakeDatasetGenerator(seed int64) *FakeDatasetGenerator {
+	return &FakeDatasetGenerator{rand: rand.New(rand.NewSource(seed)), nextID: 1}
+}
+
+// Strain generates the next synthetic Strain.  IDs are assigned sequentially
+// starting at 1.
+func (g *FakeDatasetGenerator) Strain() Strain {
+	id := g.nextID
+	g.nextID++
+
+	name := fmt.Sprintf("%s %s",
+		fakeStrainNamePrefixes[g.rand.Intn(len(fakeStrainNamePrefixes))],
+		fakeStrainNameSuffixes[g.rand.Intn(len(fakeStrainNameSuffixes))])
+
+	races := []Race{RaceIndica, RaceSativa, RaceHybrid}
+	race := races[g.rand.Intn(len(races))]
+
+	positiveEffects := fakeIndicaPositiveEffects
+	if race == RaceSativa || (race == RaceHybrid && g.rand.Intn(2) == 0) {
+		positiveEffects = fakeSativaPositiveEffects
+	}
+
+	flavorFamily := fakeFlavorFamilies[g.rand.Intn(len(fakeFlavorFamilies))]
+	flavors := make([]Flavor, 0)
+	for _, index := range g.rand.Perm(len(flavorFamily))[:1+g.rand.Intn(3)] {
+		flavors = append(flavors, flavorFamily[index])
+	}
+
+	effects := map[EffectType][]string{
+		EffectTypePositive: g.pick(positiveEffects, 2+g.rand.Intn(3)),
+		EffectTypeNegative: g.pick(fakeNegativeEffects, g.rand.Intn(3)),
+		EffectTypeMedical:  g.pick(fakeMedicalEffects, 1+g.rand.Intn(4)),
+	}
+
+	description := fmt.Sprintf("%s is a synthetic %s strain with %s notes. Users report feeling %s.",
+		name, race, flavors[0], effects[EffectTypePositive][0])
+
+	return Strain{
+		Name:        name,
+		ID:          id,
+		Description: description,
+		Race:        race,
+		Flavors:     flavors,
+		Effects:     effects,
+	}
+}
+
+// Strains generates count synthetic strains keyed by name, like the result
+// of ListAllStrains.  Duplicate names get a numeric suffix so every strain
+// is kept.
+func (g *FakeDatasetGenerator) Strains(count int) ListAllStrainsResult {
+	strains := make(ListAllStrainsResult)
+
+	for i := 0; i < count; i++ {
+		strain := g.Strain()
+
+		baseName := strain.Name
+		for suffix := 2; ; suffix++ {
+			if _, exists := strains[strain.Name]; !exists {
+				break
+			}
+			strain.Name = fmt.Sprintf("%s #%d", baseName, suffix)
+		}
+
+		strains[strain.Name] = strain
+	}
+
+	return strains
+}
+
+// Effects returns every Effect the generator can assign to a strain.
+func (g *FakeDatasetGenerator) Effects() []Effect {
+	effects := make([]Effect, 0)
+	seen := make(map[string]bool)
+
+	for _, names := range [][]string{fakeIndicaPositiveEffects, fakeSativaPositiveEffects} {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				effects = append(effects, Effect{Name: name, Type: EffectTypePositive})
+			}
+		}
+	}
+
+	for _, name := range fakeNegativeEffects {
+		effects = append(effects, Effect{Name: name, Type: EffectTypeNegative})
+	}
+
+	for _, name := range fakeMedicalEffects {
+		effects = append(effects, Effect{Name: name, Type: EffectTypeMedical})
+	}
+
+	return effects
+}
+
+// Flavors returns every Flavor the generator can assign to a strain.
+func (g *FakeDatasetGenerator) Flavors() []Flavor {
+	flavors := make([]Flavor, 0)
+	seen := make(map[Flavor]bool)
+
+	for _, family := range fakeFlavorFamilies {
+		for _, flavor := range family {
+			if !seen[flavor] {
+				seen[flavor] = true
+				flavors = append(flavors, flavor)
+			}
+		}
+	}
+
+	return flavors
+}
+
+// pick returns count distinct values from choices in random order.
+func (g *FakeDatasetGenerator) pick(choices []string, count int) []string {
+	picked := make([]string, 0)
+
+	for _, index := range g.rand.Perm(len(choices))[:count] {
+		picked = append(picked, choices[index])
+	}
+
+	return picked
+}
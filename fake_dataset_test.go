@@ -0,0 +1,43 @@
+package strainapiclient
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFakeDatasetGeneratorIsDeterministic(t *testing.T) {
+	expected := NewFakeDatasetGenerator(42).Strains(50)
+	actual := NewFakeDatasetGenerator(42).Strains(50)
+
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("Expected the same seed to generate the same strains; diff: %s", cmp.Diff(expected, actual))
+	}
+
+	if other := NewFakeDatasetGenerator(43).Strains(50); cmp.Equal(expected, other) {
+		t.Error("Expected different seeds to generate different strains")
+	}
+}
+
+func TestFakeDatasetGeneratorStrains(t *testing.T) {
+	expectedCount := 200
+	strains := NewFakeDatasetGenerator(7).Strains(expectedCount)
+
+	if len(strains) != expectedCount {
+		t.Errorf("Expected %d strains but got %d", expectedCount, len(strains))
+	}
+
+	for name, strain := range strains {
+		if strain.Name != name || strain.ID == 0 || strain.Description == "" || len(strain.Flavors) == 0 {
+			t.Errorf("Expected a fully populated strain keyed by its name; got %v", strain)
+		}
+
+		if strain.Race != RaceIndica && strain.Race != RaceSativa && strain.Race != RaceHybrid {
+			t.Errorf("Unexpected race %s for strain %s", strain.Race, name)
+		}
+
+		if len(strain.Effects[EffectTypePositive]) < 2 || len(strain.Effects[EffectTypeMedical]) < 1 {
+			t.Errorf("Expected positive and medical effects for strain %s; got %v", name, strain.Effects)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// FallbackClient is a Client that answers from a primary Client and, when
+// the primary is unavailable, from a fallback Client instead.  Pair a
+// DefaultClient with a FileBackedClient over a snapshot taken with
+// TakeSnapshot to keep serving data when the network or API is down.
+//
+// The fallback is only used when the primary fails with a server error,
+// rate limit, open circuit breaker, timeout, or network error; other
+// errors, such as ErrNotFound, are returned as-is.
+type FallbackClient struct {
+	primary   Client
+	fallback  Client
+	decisions decisionHooks
+}
+
+// NewFallbackClient creates a new FallbackClient that calls primary and
+// falls back to fallback when primary is unavailable.
+func NewFallbackClient(primary Client, fallback Client) *FallbackClient {
+	return &FallbackClient{primary: primary, fallback: fallback}
+}
+
+// isUnavailable reports whether err means the API couldn't be reached or
+// couldn't answer, rather than that it answered with an error.
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrServerError) || errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// fallBack reports whether err means the primary Client was unavailable
+// for method, tracing the decision to call the fallback.
+func (c *FallbackClient) fallBack(method string, err error) bool {
+	if !isUnavailable(err) {
+		return false
+	}
+
+	c.decisions.emit(DecisionFallback, "FallbackClient", method, err.Error())
+	return true
+}
+
+// ListAllEffects implements Client.
+func (c *FallbackClient) ListAllEffects() ([]Effect, error) {
+	effects, err := c.primary.ListAllEffects()
+	if c.fallBack("ListAllEffects", err) {
+		return c.fallback.ListAllEffects()
+	}
+
+	return effects, err
+}
+
+// ListAllFlavors implements Client.
+func (c *FallbackClient) ListAllFlavors() ([]Flavor, error) {
+	flavors, err := c.primary.ListAllFlavors()
+	if c.fallBack("ListAllFlavors", err) {
+		return c.fallback.ListAllFlavors()
+	}
+
+	return flavors, err
+}
+
+// ListAllStrains implements Client.
+func (c *FallbackClient) ListAllStrains() (ListAllStrainsResult, error) {
+	strains, err := c.primary.ListAllStrains()
+	if c.fallBack("ListAllStrains", err) {
+		return c.fallback.ListAllStrains()
+	}
+
+	return strains, err
+}
+
+// SearchStrainsByName implements Client.
+func (c *FallbackClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	results, err := c.primary.SearchStrainsByName(name)
+	if c.fallBack("SearchStrainsByName", err) {
+		return c.fallback.SearchStrainsByName(name)
+	}
+
+	return results, err
+}
+
+// SearchStrainsByRace implements Client.
+func (c *FallbackClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	results, err := c.primary.SearchStrainsByRace(race)
+	if c.fallBack("SearchStrainsByRace", err) {
+		return c.fallback.SearchStrainsByRace(race)
+	}
+
+	return results, err
+}
+
+// SearchStrainsByFlavor implements Client.
+func (c *FallbackClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	results, err := c.primary.SearchStrainsByFlavor(flavor)
+	if c.fallBack("SearchStrainsByFlavor", err) {
+		return c.fallback.SearchStrainsByFlavor(flavor)
+	}
+
+	return results, err
+}
+
+// SearchStrainsByEffectName implements Client.
+func (c *FallbackClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	results, err := c.primary.SearchStrainsByEffectName(effectName)
+	if c.fallBack("SearchStrainsByEffectName", err) {
+		return c.fallback.SearchStrainsByEffectName(effectName)
+	}
+
+	return results, err
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (c *FallbackClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	description, err := c.primary.GetStrainDescriptionByStrainID(id)
+	if c.fallBack("GetStrainDescriptionByStrainID", err) {
+		return c.fallback.GetStrainDescriptionByStrainID(id)
+	}
+
+	return description, err
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (c *FallbackClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	flavors, err := c.primary.GetStrainFlavorsByStrainID(id)
+	if c.fallBack("GetStrainFlavorsByStrainID", err) {
+		return c.fallback.GetStrainFlavorsByStrainID(id)
+	}
+
+	return flavors, err
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (c *FallbackClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	effects, err := c.primary.GetStrainEffectsByStrainID(id)
+	if c.fallBack("GetStrainEffectsByStrainID", err) {
+		return c.fallback.GetStrainEffectsByStrainID(id)
+	}
+
+	return effects, err
+}
+
+// SetHandleResourceRequestFunc implements Client by setting the handler
+// on the primary Client.
+func (c *FallbackClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return c.primary.SetHandleResourceRequestFunc(f)
+}
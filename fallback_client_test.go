@@ -0,0 +1,27 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFallbackClient(t *testing.T) {
+	var statusCode int
+	primary := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	primary.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: statusCode}
+	})
+
+	fallback := NewSnapshotClient(Snapshot{Flavors: []Flavor{"Earthy"}})
+	client := NewFallbackClient(primary, fallback)
+
+	statusCode = 503
+	if flavors, err := client.ListAllFlavors(); err != nil || len(flavors) != 1 {
+		t.Errorf("Expected the fallback flavors when the API is down but got %v and %v", flavors, err)
+	}
+
+	statusCode = 404
+	if _, err := client.ListAllFlavors(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound from the primary to be returned but got %v", err)
+	}
+}
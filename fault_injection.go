@@ -0,0 +1,121 @@
+package strainapiclient
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FaultInjectionConfig controls the faults a FaultInjector adds to requests.
+// Rates are probabilities between 0 and 1 checked independently per request.
+type FaultInjectionConfig struct {
+	// MaxLatency is the upper bound of a random delay added to every request.
+	MaxLatency time.Duration
+	// ErrorRate is the chance a request fails with an ErrorStatusCode error.
+	ErrorRate float64
+	// ErrorStatusCode is the status of the errors injected at ErrorRate
+	// (default 500).
+	ErrorStatusCode int
+	// TruncateRate is the chance a successful response body is cut in half.
+	TruncateRate float64
+	// RateLimitBurstRate is the chance a request starts a burst of 429 responses.
+	RateLimitBurstRate float64
+	// RateLimitBurstLength is how many consecutive requests a 429 burst lasts.
+	RateLimitBurstLength int
+	// Seed seeds the random source so fault sequences are reproducible.
+	Seed int64
+}
+
+// FaultInjector adds random latency, errors, truncated bodies, and bursts of
+// 429 responses to requests, so retry and stale-serving behavior can be
+// exercised deliberately in tests and staging.  Pass it to WithFaultInjector
+// to inject faults into each attempt of the default request handler, where
+// the RetryPolicy and timeouts see them, or use Wrap or WrapContext to
+// inject them around a whole request handler.
+type FaultInjector struct {
+	config         FaultInjectionConfig
+	rand           *rand.Rand
+	mutex          sync.Mutex
+	burstRemaining int
+}
+
+// NewFaultInjector creates a new FaultInjector with the config passed in.
+func NewFaultInjector(config FaultInjectionConfig) *FaultInjector {
+	return &FaultInjector{config: config, rand: rand.New(rand.NewSource(config.Seed))}
+}
+
+// Wrap returns a HandleResourceRequestFunc that injects faults around next.
+func (f *FaultInjector) Wrap(next HandleResourceRequestFunc) HandleResourceRequestFunc {
+	wrapped := f.WrapContext(func(ctx context.Context, resourcePath string) ([]byte, error) {
+		return next(resourcePath)
+	})
+
+	return func(resourcePath string) ([]byte, error) {
+		return wrapped(context.Background(), resourcePath)
+	}
+}
+
+// WrapContext returns a HandleResourceRequestContextFunc that injects faults
+// around next.  Injected latency is cut short if the context is done.
+func (f *FaultInjector) WrapContext(next HandleResourceRequestContextFunc) HandleResourceRequestContextFunc {
+	return func(ctx context.Context, resourcePath string) ([]byte, error) {
+		return f.inject(ctx, resourcePath, next)
+	}
+}
+
+// inject calls next for resourcePath with the faults decided by roll.
+func (f *FaultInjector) inject(ctx context.Context, resourcePath string, next HandleResourceRequestContextFunc) ([]byte, error) {
+	latency, statusCode, truncate := f.roll()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return make([]byte, 0), ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if statusCode != 0 {
+		return make([]byte, 0), &APIError{StatusCode: statusCode, Body: "injected fault"}
+	}
+
+	body, err := next(ctx, resourcePath)
+	if err == nil && truncate {
+		body = body[:len(body)/2]
+	}
+
+	return body, err
+}
+
+// roll decides which faults apply to the next request.
+func (f *FaultInjector) roll() (latency time.Duration, statusCode int, truncate bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.config.MaxLatency > 0 {
+		latency = time.Duration(f.rand.Int63n(int64(f.config.MaxLatency)))
+	}
+
+	if f.burstRemaining == 0 && f.rand.Float64() < f.config.RateLimitBurstRate {
+		f.burstRemaining = f.config.RateLimitBurstLength
+	}
+
+	switch {
+	case f.burstRemaining > 0:
+		f.burstRemaining--
+		statusCode = http.StatusTooManyRequests
+	case f.rand.Float64() < f.config.ErrorRate:
+		statusCode = f.config.ErrorStatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+	}
+
+	truncate = f.rand.Float64() < f.config.TruncateRate
+
+	return latency, statusCode, truncate
+}
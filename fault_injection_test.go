@@ -0,0 +1,61 @@
+package strainapiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func alwaysEffectsHandler(path string) ([]byte, error) {
+	return []byte("[{\"effect\": \"Relaxed\", \"type\": \"positive\"}]"), nil
+}
+
+func TestFaultInjectorErrorRate(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(NewFaultInjector(FaultInjectionConfig{ErrorRate: 1}).Wrap(alwaysEffectsHandler))
+
+	_, err := client.ListAllEffects()
+	if err == nil || !strings.HasPrefix(err.Error(), "Status: 500") {
+		t.Errorf("Expected an injected 500 error but got %v", err)
+	}
+}
+
+func TestFaultInjectorRateLimitBurst(t *testing.T) {
+	injector := NewFaultInjector(FaultInjectionConfig{RateLimitBurstRate: 1, RateLimitBurstLength: 3})
+	handler := injector.Wrap(alwaysEffectsHandler)
+
+	for i := 0; i < 3; i++ {
+		if _, err := handler("/path"); err == nil || !strings.HasPrefix(err.Error(), "Status: 429") {
+			t.Errorf("Expected request %d of the burst to get a 429 but got %v", i, err)
+		}
+	}
+}
+
+func TestFaultInjectorTruncatesBodies(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(NewFaultInjector(FaultInjectionConfig{TruncateRate: 1}).Wrap(alwaysEffectsHandler))
+
+	if _, err := client.ListAllEffects(); err == nil {
+		t.Error("Expected a truncated body to fail to parse but got no error")
+	}
+}
+
+func TestWithFaultInjectorRetriesInjectedErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	// With seed 2, the first attempt gets an injected 503 and the second doesn't.
+	injector := NewFaultInjector(FaultInjectionConfig{ErrorRate: 0.5, ErrorStatusCode: http.StatusServiceUnavailable, Seed: 2})
+	client := NewClient("test-key", WithBaseURL(server.URL), WithFaultInjector(injector),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second}))
+
+	if _, err := client.ListAllFlavors(); err != nil || requests != 1 {
+		t.Errorf("Expected the injected 503 to be retried and the retry to reach the server; got %d requests (error: %v)", requests, err)
+	}
+}
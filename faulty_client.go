@@ -0,0 +1,214 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MethodFaults controls the faults a FaultyClient injects into calls to a
+// method.  Rates are probabilities between 0 and 1 checked independently
+// per call, in the order the fields are listed.
+type MethodFaults struct {
+	// LatencyRate is the chance Latency is added before the call.
+	LatencyRate float64
+	Latency     time.Duration
+	// TimeoutRate is the chance the call waits Timeout and then fails with
+	// a net.Error whose Timeout method returns true.
+	TimeoutRate float64
+	Timeout     time.Duration
+	// StatusErrorRate is the chance the call fails with an *APIError with
+	// StatusCode (500 if unset).
+	StatusErrorRate float64
+	StatusCode      int
+	// MalformedJSONRate is the chance the call fails the way it would if
+	// the API returned a body that isn't valid JSON.
+	MalformedJSONRate float64
+}
+
+// FaultyClient is a Client that wraps another Client and injects latency,
+// timeouts, status errors, and malformed JSON failures per method, so
+// consumers can exercise their resilience paths.  Faults are configured
+// with SetFaults using Client method names such as "ListAllEffects";
+// methods without their own configuration use the default faults.
+//
+// Unlike FaultInjector, which works on the raw requests of a
+// DefaultClient, FaultyClient works with any Client implementation.
+type FaultyClient struct {
+	client Client
+
+	mutex         sync.Mutex
+	rand          *rand.Rand
+	defaultFaults MethodFaults
+	methodFaults  map[string]MethodFaults
+}
+
+// NewFaultyClient creates a new FaultyClient wrapping client that injects
+// defaultFaults into every method.  seed seeds the random source so fault
+// sequences are reproducible.
+func NewFaultyClient(client Client, defaultFaults MethodFaults, seed int64) *FaultyClient {
+	return &FaultyClient{
+		client:        client,
+		rand:          rand.New(rand.NewSource(seed)),
+		defaultFaults: defaultFaults,
+		methodFaults:  make(map[string]MethodFaults),
+	}
+}
+
+// SetFaults sets the faults injected into calls to method and returns the
+// faults previously used for it.
+func (f *FaultyClient) SetFaults(method string, faults MethodFaults) MethodFaults {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	previous, ok := f.methodFaults[method]
+	if !ok {
+		previous = f.defaultFaults
+	}
+	f.methodFaults[method] = faults
+
+	return previous
+}
+
+// faultTimeoutError is the net.Error returned for injected timeouts.
+type faultTimeoutError struct{}
+
+func (faultTimeoutError) Error() string   { return "Injected timeout" }
+func (faultTimeoutError) Timeout() bool   { return true }
+func (faultTimeoutError) Temporary() bool { return true }
+func (faultTimeoutError) Code() Code      { return CodeTimeout }
+
+// inject applies the faults configured for method and returns the error
+// the call should fail with, if any.
+func (f *FaultyClient) inject(method string) error {
+	f.mutex.Lock()
+	faults, ok := f.methodFaults[method]
+	if !ok {
+		faults = f.defaultFaults
+	}
+	addLatency := f.rand.Float64() < faults.LatencyRate
+	timeout := f.rand.Float64() < faults.TimeoutRate
+	statusError := f.rand.Float64() < faults.StatusErrorRate
+	malformed := f.rand.Float64() < faults.MalformedJSONRate
+	f.mutex.Unlock()
+
+	if addLatency {
+		time.Sleep(faults.Latency)
+	}
+
+	switch {
+	case timeout:
+		time.Sleep(faults.Timeout)
+		return fmt.Errorf("Problem calling %s: %w", method, faultTimeoutError{})
+	case statusError:
+		statusCode := faults.StatusCode
+		if statusCode == 0 {
+			statusCode = 500
+		}
+		return &APIError{StatusCode: statusCode, Body: "injected fault"}
+	case malformed:
+		var value interface{}
+		marshallErr := json.Unmarshal([]byte(`[{"name": "Relaxed", "type"`), &value)
+		return fmt.Errorf("Problem parsing %s response: %w", method, marshallErr)
+	}
+
+	return nil
+}
+
+// ListAllEffects implements Client.
+func (f *FaultyClient) ListAllEffects() ([]Effect, error) {
+	if err := f.inject("ListAllEffects"); err != nil {
+		return make([]Effect, 0), err
+	}
+
+	return f.client.ListAllEffects()
+}
+
+// ListAllFlavors implements Client.
+func (f *FaultyClient) ListAllFlavors() ([]Flavor, error) {
+	if err := f.inject("ListAllFlavors"); err != nil {
+		return make([]Flavor, 0), err
+	}
+
+	return f.client.ListAllFlavors()
+}
+
+// ListAllStrains implements Client.
+func (f *FaultyClient) ListAllStrains() (ListAllStrainsResult, error) {
+	if err := f.inject("ListAllStrains"); err != nil {
+		return make(ListAllStrainsResult), err
+	}
+
+	return f.client.ListAllStrains()
+}
+
+// SearchStrainsByName implements Client.
+func (f *FaultyClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	if err := f.inject("SearchStrainsByName"); err != nil {
+		return make(SearchStrainsByNameResults, 0), err
+	}
+
+	return f.client.SearchStrainsByName(name)
+}
+
+// SearchStrainsByRace implements Client.
+func (f *FaultyClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	if err := f.inject("SearchStrainsByRace"); err != nil {
+		return make(SearchStrainsByRaceResults, 0), err
+	}
+
+	return f.client.SearchStrainsByRace(race)
+}
+
+// SearchStrainsByFlavor implements Client.
+func (f *FaultyClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	if err := f.inject("SearchStrainsByFlavor"); err != nil {
+		return make(SearchStrainsByFlavorResults, 0), err
+	}
+
+	return f.client.SearchStrainsByFlavor(flavor)
+}
+
+// SearchStrainsByEffectName implements Client.
+func (f *FaultyClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	if err := f.inject("SearchStrainsByEffectName"); err != nil {
+		return make(SearchStrainsByEffectNameResults, 0), err
+	}
+
+	return f.client.SearchStrainsByEffectName(effectName)
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (f *FaultyClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	if err := f.inject("GetStrainDescriptionByStrainID"); err != nil {
+		return "", err
+	}
+
+	return f.client.GetStrainDescriptionByStrainID(id)
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (f *FaultyClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	if err := f.inject("GetStrainFlavorsByStrainID"); err != nil {
+		return make([]Flavor, 0), err
+	}
+
+	return f.client.GetStrainFlavorsByStrainID(id)
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (f *FaultyClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	if err := f.inject("GetStrainEffectsByStrainID"); err != nil {
+		return make(EffectsByEffectType), err
+	}
+
+	return f.client.GetStrainEffectsByStrainID(id)
+}
+
+// SetHandleResourceRequestFunc implements Client by setting the handler
+// on the wrapped Client.
+func (f *FaultyClient) SetHandleResourceRequestFunc(handler HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return f.client.SetHandleResourceRequestFunc(handler)
+}
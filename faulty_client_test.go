@@ -0,0 +1,76 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func newFaultyTestClient(defaultFaults MethodFaults) *FaultyClient {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(alwaysEffectsHandler)
+	return NewFaultyClient(client, defaultFaults, 1)
+}
+
+func TestFaultyClientNoFaults(t *testing.T) {
+	client := newFaultyTestClient(MethodFaults{})
+
+	effects, err := client.ListAllEffects()
+	if err != nil || len(effects) != 1 {
+		t.Errorf("Expected 1 effect and no error but got %d effects and %v", len(effects), err)
+	}
+}
+
+func TestFaultyClientStatusError(t *testing.T) {
+	client := newFaultyTestClient(MethodFaults{StatusErrorRate: 1, StatusCode: 429})
+
+	_, err := client.ListAllEffects()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited but got %v", err)
+	}
+}
+
+func TestFaultyClientTimeout(t *testing.T) {
+	client := newFaultyTestClient(MethodFaults{TimeoutRate: 1, Timeout: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, err := client.ListAllFlavors()
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected a timeout net.Error but got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected the timeout to take at least 10ms but took %s", elapsed)
+	}
+}
+
+func TestFaultyClientMalformedJSON(t *testing.T) {
+	client := newFaultyTestClient(MethodFaults{MalformedJSONRate: 1})
+
+	_, err := client.GetStrainDescriptionByStrainID(1)
+
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected a *json.SyntaxError but got %v", err)
+	}
+}
+
+func TestFaultyClientPerMethodFaults(t *testing.T) {
+	client := newFaultyTestClient(MethodFaults{})
+	previous := client.SetFaults("ListAllEffects", MethodFaults{StatusErrorRate: 1})
+
+	if previous != (MethodFaults{}) {
+		t.Errorf("Expected the previous faults to be the defaults but got %+v", previous)
+	}
+
+	if _, err := client.ListAllEffects(); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError from ListAllEffects but got %v", err)
+	}
+
+	if _, err := client.ListAllStrains(); errors.Is(err, ErrServerError) {
+		t.Errorf("Expected no injected fault for ListAllStrains but got %v", err)
+	}
+}
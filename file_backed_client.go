@@ -0,0 +1,332 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileBackedClient is a Client that answers every call from a Snapshot
+// instead of the API, so demos, tests, and air-gapped deployments can run
+// with no network access.  Searches follow the API's behavior: names match
+// case-insensitively on any part of the name, and results are in ID order.
+//
+// PutStrain adds or replaces strains (CompositeClient uses it to backfill
+// data fetched from the API), and AddLocalStrain adds custom strains with
+// local IDs (see IsLocalStrainID).  DeleteStrain soft-deletes strains, and
+// every change to a stored strain is kept (see GetStrainHistory).  The
+// snapshot's maps are never modified in place, so earlier results are
+// unaffected.
+type FileBackedClient struct {
+	dataMutex  sync.RWMutex
+	snapshot   Snapshot
+	byID       map[int]Strain
+	tombstones map[int]Tombstone
+	history    map[int][]StrainRevision
+	allocator  IDAllocator
+
+	mutex          sync.Mutex
+	requestHandler HandleResourceRequestFunc
+}
+
+// NewFileBackedClient creates a new FileBackedClient serving the Snapshot
+// saved at path.
+func NewFileBackedClient(path string) (*FileBackedClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Problem opening snapshot %s: %w", path, err)
+	}
+	defer file.Close()
+
+	snapshot, err := LoadSnapshot(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSnapshotClient(snapshot), nil
+}
+
+// NewSnapshotClient creates a new FileBackedClient serving snapshot, which
+// is already in memory.
+func NewSnapshotClient(snapshot Snapshot) *FileBackedClient {
+	byID := make(map[int]Strain)
+	for _, strain := range snapshot.Strains {
+		byID[strain.ID] = strain
+	}
+
+	return &FileBackedClient{snapshot: snapshot, byID: byID}
+}
+
+// data returns the current snapshot and its strains by ID, which must not
+// be modified.
+func (c *FileBackedClient) data() (Snapshot, map[int]Strain) {
+	c.dataMutex.RLock()
+	defer c.dataMutex.RUnlock()
+
+	return c.snapshot, c.byID
+}
+
+// PutStrain adds strains to the data the client serves, replacing any
+// strain with the same ID.  Strains with no Name are ignored since strains
+// are listed by name.
+func (c *FileBackedClient) PutStrain(strains ...Strain) {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	c.putStrains(strains...)
+}
+
+// MergeStrain adds strains to the data the client serves like PutStrain,
+// but for strains the client already has, it only replaces the fields that
+// are set: an empty description and nil or empty flavors, effects, and
+// extensions keep the stored values.  Use it to add strains listed by the
+// API without losing descriptions fetched earlier.
+func (c *FileBackedClient) MergeStrain(strains ...Strain) {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	merged := make([]Strain, 0)
+	for _, strain := range strains {
+		if existing, found := c.byID[strain.ID]; found {
+			if strain.Description == "" {
+				strain.Description = existing.Description
+			}
+			if len(strain.Flavors) == 0 && existing.Flavors != nil {
+				strain.Flavors = existing.Flavors
+			}
+			if len(strain.Effects) == 0 && existing.Effects != nil {
+				strain.Effects = existing.Effects
+			}
+			if len(strain.Extensions) == 0 && existing.Extensions != nil {
+				strain.Extensions = existing.Extensions
+			}
+		}
+		merged = append(merged, strain)
+	}
+
+	c.putStrains(merged...)
+}
+
+// updateStrain applies update to the strain with id and stores the result,
+// if the client has that strain.
+func (c *FileBackedClient) updateStrain(id int, update func(strain *Strain)) {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	strain, found := c.byID[id]
+	if !found {
+		return
+	}
+
+	update(&strain)
+	c.putStrains(strain)
+}
+
+// putStrains records the changes to strains in their history and stores
+// them; the caller holds dataMutex.
+func (c *FileBackedClient) putStrains(strains ...Strain) {
+	c.recordRevisions(time.Now(), strains...)
+	c.storeStrains(strains...)
+}
+
+// storeStrains replaces the snapshot's maps with copies that include
+// strains; the caller holds dataMutex.
+func (c *FileBackedClient) storeStrains(strains ...Strain) {
+	replaced := make(map[int]Strain)
+	for _, strain := range strains {
+		if strain.Name != "" {
+			replaced[strain.ID] = strain
+		}
+	}
+
+	if len(replaced) == 0 {
+		return
+	}
+
+	byName := make(ListAllStrainsResult)
+	for name, existing := range c.snapshot.Strains {
+		if _, found := replaced[existing.ID]; !found {
+			byName[name] = existing
+		}
+	}
+
+	byID := make(map[int]Strain)
+	for id, existing := range c.byID {
+		byID[id] = existing
+	}
+
+	for id, strain := range replaced {
+		byName[strain.Name] = strain
+		byID[id] = strain
+		delete(c.tombstones, id)
+	}
+
+	c.snapshot.Strains = byName
+	c.byID = byID
+}
+
+// sortedStrains returns the snapshot's strains in ID order, like the API.
+func (c *FileBackedClient) sortedStrains() []Strain {
+	snapshot, _ := c.data()
+
+	strains := make([]Strain, 0)
+	for _, strain := range snapshot.Strains {
+		strains = append(strains, strain)
+	}
+
+	sort.Slice(strains, func(i, j int) bool { return strains[i].ID < strains[j].ID })
+	return strains
+}
+
+// strain returns the strain with id or an error wrapping ErrNotFound.
+func (c *FileBackedClient) strain(id int) (Strain, error) {
+	_, byID := c.data()
+
+	strain, found := byID[id]
+	if !found {
+		return strain, fmt.Errorf("Strain with ID %d is not in the snapshot: %w", id, ErrNotFound)
+	}
+
+	return strain, nil
+}
+
+// ListAllEffects implements Client.
+func (c *FileBackedClient) ListAllEffects() ([]Effect, error) {
+	snapshot, _ := c.data()
+	return append(make([]Effect, 0), snapshot.Effects...), nil
+}
+
+// ListAllFlavors implements Client.
+func (c *FileBackedClient) ListAllFlavors() ([]Flavor, error) {
+	snapshot, _ := c.data()
+	return append(make([]Flavor, 0), snapshot.Flavors...), nil
+}
+
+// ListAllStrains implements Client.
+func (c *FileBackedClient) ListAllStrains() (ListAllStrainsResult, error) {
+	snapshot, _ := c.data()
+
+	strains := make(ListAllStrainsResult)
+	for name, strain := range snapshot.Strains {
+		strains[name] = strain
+	}
+
+	return strains, nil
+}
+
+// SearchStrainsByName implements Client.
+func (c *FileBackedClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	results := make(SearchStrainsByNameResults, 0)
+
+	for _, strain := range c.sortedStrains() {
+		if strings.Contains(strings.ToLower(strain.Name), strings.ToLower(name)) {
+			results = append(results, SearchStrainsByNameResult{
+				Name: strain.Name, ID: strain.ID, Description: strain.Description, Race: strain.Race,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByRace implements Client.
+func (c *FileBackedClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	results := make(SearchStrainsByRaceResults, 0)
+
+	for _, strain := range c.sortedStrains() {
+		if strain.Race == race {
+			results = append(results, SearchStrainsByRaceResult{Name: strain.Name, ID: strain.ID, Race: strain.Race})
+		}
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByFlavor implements Client.
+func (c *FileBackedClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	results := make(SearchStrainsByFlavorResults, 0)
+
+	for _, strain := range c.sortedStrains() {
+		for _, strainFlavor := range strain.Flavors {
+			if strainFlavor == flavor {
+				results = append(results, SearchStrainsByFlavorResult{Name: strain.Name, ID: strain.ID, Race: strain.Race, Flavor: flavor})
+				break
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByEffectName implements Client.
+func (c *FileBackedClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	results := make(SearchStrainsByEffectNameResults, 0)
+
+	for _, strain := range c.sortedStrains() {
+		for _, name := range allEffectNames(strain) {
+			if name == effectName {
+				results = append(results, SearchStrainsByEffectNameResult{Name: strain.Name, ID: strain.ID, Race: strain.Race, EffectName: effectName})
+				break
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// GetStrainDescriptionByStrainID implements Client.
+func (c *FileBackedClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	strain, err := c.strain(id)
+	if err != nil {
+		return "", err
+	}
+
+	if strain.Description == "" {
+		return "", fmt.Errorf("Strain with ID %d has no description in the snapshot: %w", id, ErrNotFound)
+	}
+
+	return strain.Description, nil
+}
+
+// GetStrainFlavorsByStrainID implements Client.
+func (c *FileBackedClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	strain, err := c.strain(id)
+	if err != nil {
+		return make([]Flavor, 0), err
+	}
+
+	return append(make([]Flavor, 0), strain.Flavors...), nil
+}
+
+// GetStrainEffectsByStrainID implements Client.
+func (c *FileBackedClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	effects := make(EffectsByEffectType)
+
+	strain, err := c.strain(id)
+	if err != nil {
+		return effects, err
+	}
+
+	for effectType, names := range strain.Effects {
+		effects[effectType] = make([]Effect, 0)
+		for _, name := range names {
+			effects[effectType] = append(effects[effectType], Effect{Name: name, Type: effectType})
+		}
+	}
+
+	return effects, nil
+}
+
+// SetHandleResourceRequestFunc implements Client.  The FileBackedClient
+// never makes requests, so the handler is only stored.
+func (c *FileBackedClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	current := c.requestHandler
+	c.requestHandler = f
+	return current
+}
@@ -0,0 +1,103 @@
+package strainapiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBackedClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-snapshot")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := Snapshot{
+		Effects: []Effect{{Name: "Relaxed", Type: EffectTypePositive}},
+		Flavors: []Flavor{"Earthy", "Sweet"},
+		Strains: ListAllStrainsResult{
+			"Afpak": {Name: "Afpak", ID: 1, Race: RaceHybrid, Description: "An indica-dominant hybrid.",
+				Flavors: []Flavor{"Earthy"}, Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed"}}},
+			"Blue Dream": {Name: "Blue Dream", ID: 2, Race: RaceHybrid, Flavors: []Flavor{"Sweet"}},
+		},
+	}
+
+	path := filepath.Join(dir, "snapshot.json")
+	file, _ := os.Create(path)
+	snapshot.Write(file)
+	file.Close()
+
+	client, err := NewFileBackedClient(path)
+	if err != nil {
+		t.Errorf("Expected no error creating client but got: %s", err)
+		return
+	}
+
+	if results, _ := client.SearchStrainsByName("blue"); len(results) != 1 || results[0].ID != 2 {
+		t.Errorf("Expected Blue Dream when searching for 'blue' but got %v", results)
+	}
+
+	if results, _ := client.SearchStrainsByRace(RaceHybrid); len(results) != 2 || results[0].ID != 1 {
+		t.Errorf("Expected 2 hybrids in ID order but got %v", results)
+	}
+
+	if results, _ := client.SearchStrainsByEffectName("Relaxed"); len(results) != 1 || results[0].Name != "Afpak" {
+		t.Errorf("Expected Afpak when searching for Relaxed but got %v", results)
+	}
+
+	effects, _ := client.GetStrainEffectsByStrainID(1)
+	if positive := effects[EffectTypePositive]; len(positive) != 1 || positive[0] != (Effect{Name: "Relaxed", Type: EffectTypePositive}) {
+		t.Errorf("Expected Relaxed as a positive effect but got %v", effects)
+	}
+
+	if _, err := client.GetStrainDescriptionByStrainID(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing description but got %v", err)
+	}
+
+	if _, err := client.GetStrainFlavorsByStrainID(99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown ID but got %v", err)
+	}
+}
+
+func TestFileBackedClientPutStrain(t *testing.T) {
+	client := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{"Afpak": {Name: "Afpak", ID: 1}}})
+	before, _ := client.ListAllStrains()
+
+	client.PutStrain(Strain{Name: "Afpak #2", ID: 1, Description: "Renamed"})
+	client.PutStrain(Strain{Name: "Blue Dream", ID: 2})
+	client.PutStrain(Strain{ID: 3})
+
+	strains, _ := client.ListAllStrains()
+	if len(strains) != 2 || strains["Afpak #2"].Description != "Renamed" || strains["Blue Dream"].ID != 2 {
+		t.Errorf("Expected the replaced and added strains but got %v", strains)
+	}
+
+	if description, _ := client.GetStrainDescriptionByStrainID(1); description != "Renamed" {
+		t.Errorf("Expected the replaced strain's description but got '%s'", description)
+	}
+
+	if len(before) != 1 {
+		t.Errorf("Expected earlier results to be unaffected but got %v", before)
+	}
+}
+
+func TestFileBackedClientMergeStrain(t *testing.T) {
+	client := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{
+		"Afpak": {Name: "Afpak", ID: 1, Race: RaceHybrid, Description: "Stored", Flavors: []Flavor{"Earthy"}},
+	}})
+
+	client.MergeStrain(Strain{Name: "Afpak", ID: 1, Race: RaceIndica}, Strain{Name: "Blue Dream", ID: 2, Race: RaceHybrid})
+
+	afpak, _ := client.strain(1)
+	if afpak.Race != RaceIndica || afpak.Description != "Stored" || len(afpak.Flavors) != 1 {
+		t.Errorf("Expected the race to be updated and the rest kept but got %v", afpak)
+	}
+
+	if _, err := client.strain(2); err != nil {
+		t.Errorf("Expected the new strain to be added but got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const fileCacheExtension string = ".cache"
+
+// FileCache is a Cache that stores each value in its own file in a
+// directory, so short-lived processes such as CLI tools and cron jobs
+// can share cached results across runs.
+//
+// Each file holds the expiry time on its first line followed by the value.
+// Files are written to a temporary name and renamed into place so readers
+// never see a partial value.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a new FileCache storing files in dir, creating
+// the directory if it doesn't exist.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Problem creating cache directory %s: %w", dir, err)
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+// path returns the file used for key.  Keys are hashed so any key
+// makes a safe file name.
+func (f *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:])+fileCacheExtension)
+}
+
+// Get implements Cache.
+func (f *FileCache) Get(key string) ([]byte, bool, error) {
+	contents, err := ioutil.ReadFile(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("Problem reading cache file for %s: %w", key, err)
+	}
+
+	newline := bytes.IndexByte(contents, '\n')
+	if newline < 0 {
+		return nil, false, fmt.Errorf("Cache file for %s is corrupt", key)
+	}
+
+	expiresAt, err := strconv.ParseInt(string(contents[:newline]), 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("Cache file for %s is corrupt: %w", key, err)
+	}
+
+	if time.Now().UnixNano() >= expiresAt {
+		os.Remove(f.path(key))
+		return nil, false, nil
+	}
+
+	return contents[newline+1:], true, nil
+}
+
+// Set implements Cache.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) error {
+	tempFile, err := ioutil.TempFile(f.dir, "tmp-")
+	if err != nil {
+		return fmt.Errorf("Problem creating cache file for %s: %w", key, err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	header := strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10) + "\n"
+	_, err = tempFile.Write(append([]byte(header), value...))
+	closeErr := tempFile.Close()
+
+	if err != nil || closeErr != nil {
+		return fmt.Errorf("Problem writing cache file for %s: %v %v", key, err, closeErr)
+	}
+
+	if err := os.Rename(tempFile.Name(), f.path(key)); err != nil {
+		return fmt.Errorf("Problem writing cache file for %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Delete implements Cache.
+func (f *FileCache) Delete(key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Problem deleting cache file for %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Flush implements Cache by removing every cache file in the directory.
+// Other files in the directory are left alone.
+func (f *FileCache) Flush() error {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return fmt.Errorf("Problem reading cache directory %s: %w", f.dir, err)
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), fileCacheExtension) {
+			if err := os.Remove(filepath.Join(f.dir, file.Name())); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("Problem flushing cache file %s: %w", file.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package strainapiclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-file-cache")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	var cache Cache
+	cache, err = NewFileCache(filepath.Join(dir, "nested"))
+	if err != nil {
+		t.Errorf("Expected no error creating the cache but got: %s", err)
+		return
+	}
+
+	cache.Set("strains", []byte("{\"a\": 1}\n"), time.Minute)
+	cache.Set("short", []byte("x"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// A new FileCache over the same directory sees the same values
+	reopened, _ := NewFileCache(filepath.Join(dir, "nested"))
+
+	if value, found, err := reopened.Get("strains"); !found || err != nil || string(value) != "{\"a\": 1}\n" {
+		t.Errorf("Expected to find the strains value but got %q (found: %t, error: %v)", value, found, err)
+	}
+
+	if _, found, _ := reopened.Get("short"); found {
+		t.Error("Expected 'short' to have expired")
+	}
+
+	reopened.Delete("strains")
+	if _, found, _ := cache.Get("strains"); found {
+		t.Error("Expected 'strains' to have been deleted")
+	}
+
+	cache.Set("strains", []byte("1"), time.Minute)
+	cache.Flush()
+	if _, found, _ := cache.Get("strains"); found {
+		t.Error("Expected 'strains' to have been flushed")
+	}
+}
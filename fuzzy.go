@@ -0,0 +1,132 @@
+package strainapiclient
+
+import (
+	"sort"
+	"strings"
+)
+
+// levenshtein returns the number of single-character insertions,
+// deletions, and substitutions needed to turn a into b.
+func levenshtein(a string, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current := make([]int, len(target)+1)
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+
+		previous = current
+	}
+
+	return previous[len(target)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// fuzzyNameDistance is the edit distance between query and a strain name,
+// ignoring case.  A one-word query is also compared to each word of the
+// name, so "Dreem" is close to "Blue Dream".
+func fuzzyNameDistance(query string, name string) int {
+	query, name = strings.ToLower(query), strings.ToLower(name)
+	distance := levenshtein(query, name)
+
+	if len(strings.Fields(query)) == 1 {
+		for _, word := range strings.Fields(name) {
+			distance = minInt(distance, levenshtein(query, word))
+		}
+	}
+
+	return distance
+}
+
+// TypoTolerance sets how many edits a fuzzy name match allows, relative to
+// the length of the query, so short autocomplete prefixes can be matched
+// strictly while longer names in batch jobs tolerate more typos.
+type TypoTolerance struct {
+	// Relative is the fraction of the query's length, in runes, allowed in
+	// edits.
+	Relative float64
+	// MinDistance is the number of edits allowed however short the query,
+	// once it is at least MinLength long.
+	MinDistance int
+	// MinLength is the length a query must have for any edits to be
+	// allowed; shorter queries only match names they equal, ignoring case.
+	MinLength int
+}
+
+// DefaultTypoTolerance allows a third of the query's length in edits, and
+// at least 2, for queries of any length.
+var DefaultTypoTolerance = TypoTolerance{
+	Relative:    1.0 / 3,
+	MinDistance: 2,
+}
+
+// maxDistance returns the number of edits t allows for query.
+func (t TypoTolerance) maxDistance(query string) int {
+	length := len([]rune(strings.TrimSpace(query)))
+	if length < t.MinLength {
+		return 0
+	}
+
+	distance := int(t.Relative*float64(length) + 1e-9)
+	if distance < t.MinDistance {
+		distance = t.MinDistance
+	}
+
+	return distance
+}
+
+// SearchStrainsByNameFuzzy returns the strains whose names are within
+// maxDistance edits (insertions, deletions, or substitutions) of name,
+// ignoring case, since the API's name search only matches exact substrings
+// and strain names are often misspelled.  Results are sorted by distance
+// and then by name.
+func (r ListAllStrainsResult) SearchStrainsByNameFuzzy(name string, maxDistance int) []Strain {
+	results := make([]Strain, 0)
+	distances := make(map[string]int)
+
+	if strings.TrimSpace(name) == "" {
+		return results
+	}
+
+	for strainName, strain := range r {
+		if distance := fuzzyNameDistance(name, strainName); distance <= maxDistance {
+			strain.Name = strainName
+			distances[strainName] = distance
+			results = append(results, strain)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if distances[results[i].Name] != distances[results[j].Name] {
+			return distances[results[i].Name] < distances[results[j].Name]
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+// SearchStrainsByNameFuzzyWithTolerance is SearchStrainsByNameFuzzy with the
+// number of edits allowed set by tolerance for the length of name.
+func (r ListAllStrainsResult) SearchStrainsByNameFuzzyWithTolerance(name string, tolerance TypoTolerance) []Strain {
+	return r.SearchStrainsByNameFuzzy(name, tolerance.maxDistance(name))
+}
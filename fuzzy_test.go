@@ -0,0 +1,73 @@
+package strainapiclient
+
+import "testing"
+
+func TestSearchStrainsByNameFuzzy(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Blue Dream":  {ID: 1},
+		"Blue Dreams": {ID: 2},
+		"Sour Diesel": {ID: 3},
+	}
+
+	results := strains.SearchStrainsByNameFuzzy("blu dreem", 3)
+	if len(results) != 2 || results[0].Name != "Blue Dream" || results[1].Name != "Blue Dreams" {
+		t.Errorf("Expected Blue Dream then Blue Dreams but got %v", results)
+	}
+
+	results = strains.SearchStrainsByNameFuzzy("Deisel", 2)
+	if len(results) != 1 || results[0].ID != 3 {
+		t.Errorf("Expected Sour Diesel but got %v", results)
+	}
+
+	if results := strains.SearchStrainsByNameFuzzy("Kush", 1); len(results) != 0 {
+		t.Errorf("Expected no results but got %v", results)
+	}
+
+	if results := strains.SearchStrainsByNameFuzzy(" ", 5); len(results) != 0 {
+		t.Errorf("Expected no results for an empty name but got %v", results)
+	}
+}
+
+func TestSearchStrainsByNameFuzzyWithTolerance(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Blue Dream":  {ID: 1},
+		"Sour Diesel": {ID: 2},
+		"OG Kush":     {ID: 3},
+	}
+
+	if results := strains.SearchStrainsByNameFuzzyWithTolerance("Soor Deisel", DefaultTypoTolerance); len(results) != 1 || results[0].ID != 2 {
+		t.Errorf("Expected Sour Diesel but got %v", results)
+	}
+
+	strict := TypoTolerance{Relative: 0.1, MinLength: 4}
+	if results := strains.SearchStrainsByNameFuzzyWithTolerance("Soor Deisel", strict); len(results) != 0 {
+		t.Errorf("Expected no results with a strict tolerance but got %v", results)
+	}
+
+	if results := strains.SearchStrainsByNameFuzzyWithTolerance("Kus", TypoTolerance{MinDistance: 1, MinLength: 4}); len(results) != 0 {
+		t.Errorf("Expected no typos to be allowed in a short query but got %v", results)
+	}
+
+	if results := strains.SearchStrainsByNameFuzzyWithTolerance("Kuhs", TypoTolerance{MinDistance: 2, MinLength: 4}); len(results) != 1 || results[0].ID != 3 {
+		t.Errorf("Expected OG Kush but got %v", results)
+	}
+}
+
+func TestTypoToleranceMaxDistance(t *testing.T) {
+	for query, expected := range map[string]int{"ab": 2, "abcdefghi": 3, "abcdefghijkl": 4} {
+		if actual := DefaultTypoTolerance.maxDistance(query); actual != expected {
+			t.Errorf("Expected %d edits for '%s' but got %d", expected, query, actual)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{{"kitten", "sitting", 3}, {"", "abc", 3}, {"same", "same", 0}, {"flaw", "lawn", 2}} {
+		if actual := levenshtein(test.a, test.b); actual != test.expected {
+			t.Errorf("Expected distance %d between %s and %s but got %d", test.expected, test.a, test.b, actual)
+		}
+	}
+}
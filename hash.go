@@ -0,0 +1,112 @@
+package strainapiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// canonicalStrain is the normalized form of a Strain that Strain.Hash
+// hashes: flavors and effects are sorted and empty effect types dropped, so
+// the hash doesn't depend on the order the API returned them in.
+type canonicalStrain struct {
+	ID          int                     `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"desc"`
+	Race        Race                    `json:"race"`
+	Flavors     []string                `json:"flavors"`
+	Effects     map[EffectType][]string `json:"effects"`
+	Extensions  map[string]interface{}  `json:"extensions,omitempty"`
+}
+
+// canonicalSnapshot is the normalized form of a Snapshot that
+// Snapshot.Hash hashes.
+type canonicalSnapshot struct {
+	Effects []Effect          `json:"effects"`
+	Flavors []string          `json:"flavors"`
+	Strains []canonicalStrain `json:"strains"`
+}
+
+// Hash returns a SHA-256 hash (in hex) of the strain's fields.  Two strains
+// with the same fields hash the same however their flavors and effects are
+// ordered, so the hash can be stored and compared to detect changes.
+func (s Strain) Hash() string {
+	return hashCanonical(canonicalizeStrain(s))
+}
+
+// Hash returns a SHA-256 hash (in hex) of the whole dataset, which is the
+// same for two snapshots with the same data however it is ordered.  Strains
+// with no Name are hashed with the name they're keyed by.
+func (s Snapshot) Hash() string {
+	canonical := canonicalSnapshot{
+		Effects: append(make([]Effect, 0), s.Effects...),
+		Flavors: make([]string, 0),
+		Strains: make([]canonicalStrain, 0),
+	}
+
+	sort.Slice(canonical.Effects, func(i, j int) bool {
+		if canonical.Effects[i].Type != canonical.Effects[j].Type {
+			return canonical.Effects[i].Type < canonical.Effects[j].Type
+		}
+		return canonical.Effects[i].Name < canonical.Effects[j].Name
+	})
+
+	for _, flavor := range s.Flavors {
+		canonical.Flavors = append(canonical.Flavors, string(flavor))
+	}
+	sort.Strings(canonical.Flavors)
+
+	for name, strain := range s.Strains {
+		if strain.Name == "" {
+			strain.Name = name
+		}
+		canonical.Strains = append(canonical.Strains, canonicalizeStrain(strain))
+	}
+	sort.Slice(canonical.Strains, func(i, j int) bool {
+		if canonical.Strains[i].ID != canonical.Strains[j].ID {
+			return canonical.Strains[i].ID < canonical.Strains[j].ID
+		}
+		return canonical.Strains[i].Name < canonical.Strains[j].Name
+	})
+
+	return hashCanonical(canonical)
+}
+
+// canonicalizeStrain returns the normalized form of strain.
+func canonicalizeStrain(strain Strain) canonicalStrain {
+	canonical := canonicalStrain{
+		ID:          strain.ID,
+		Name:        strain.Name,
+		Description: strain.Description,
+		Race:        strain.Race,
+		Flavors:     make([]string, 0),
+		Effects:     make(map[EffectType][]string),
+		Extensions:  strain.Extensions,
+	}
+
+	for _, flavor := range strain.Flavors {
+		canonical.Flavors = append(canonical.Flavors, string(flavor))
+	}
+	sort.Strings(canonical.Flavors)
+
+	for effectType, names := range strain.Effects {
+		if len(names) == 0 {
+			continue
+		}
+		sorted := append(make([]string, 0), names...)
+		sort.Strings(sorted)
+		canonical.Effects[effectType] = sorted
+	}
+
+	return canonical
+}
+
+// hashCanonical hashes the JSON encoding of value.  encoding/json writes
+// map keys in sorted order, so maps hash consistently.
+func hashCanonical(value interface{}) string {
+	canonicalJSON, _ := json.Marshal(value)
+	sum := sha256.Sum256(canonicalJSON)
+
+	return hex.EncodeToString(sum[:])
+}
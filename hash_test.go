@@ -0,0 +1,45 @@
+package strainapiclient
+
+import "testing"
+
+func TestStrainHashIgnoresOrder(t *testing.T) {
+	strain := Strain{ID: 1, Name: "Afpak", Race: RaceHybrid, Flavors: []Flavor{"Earthy", "Pine"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Happy", "Relaxed"}, EffectTypeNegative: {}}}
+	reordered := Strain{ID: 1, Name: "Afpak", Race: RaceHybrid, Flavors: []Flavor{"Pine", "Earthy"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed", "Happy"}}}
+
+	if strain.Hash() != reordered.Hash() {
+		t.Error("Expected reordered flavors and effects to hash the same")
+	}
+
+	reordered.Description = "Changed"
+	if strain.Hash() == reordered.Hash() {
+		t.Error("Expected a changed description to change the hash")
+	}
+
+	if len(strain.Hash()) != 64 {
+		t.Errorf("Expected a hex SHA-256 hash but got %s", strain.Hash())
+	}
+}
+
+func TestSnapshotHash(t *testing.T) {
+	snapshot := newFakeSnapshot()
+	reordered := newFakeSnapshot()
+	for i, j := 0, len(reordered.Flavors)-1; i < j; i, j = i+1, j-1 {
+		reordered.Flavors[i], reordered.Flavors[j] = reordered.Flavors[j], reordered.Flavors[i]
+	}
+	reordered.Effects = append(reordered.Effects[1:], reordered.Effects[0])
+
+	if snapshot.Hash() != reordered.Hash() {
+		t.Error("Expected reordered snapshots to hash the same")
+	}
+
+	for name, strain := range reordered.Strains {
+		strain.Race = "other"
+		reordered.Strains[name] = strain
+		break
+	}
+	if snapshot.Hash() == reordered.Hash() {
+		t.Error("Expected a changed strain to change the snapshot hash")
+	}
+}
@@ -0,0 +1,74 @@
+package strainapiclient
+
+import (
+	"context"
+	"time"
+)
+
+// hedgingContextKey is the context key WithHedging stores the delay under.
+type hedgingContextKey struct{}
+
+// WithHedging returns a copy of ctx that makes the DefaultClient's
+// ...Context methods hedge their requests: if a request hasn't answered
+// within delay, a second identical request is sent, the first successful
+// response wins, and the other request is cancelled.  This cuts tail
+// latency for interactive lookups on flaky networks at the cost of extra
+// requests, so pick a delay around a high percentile (such as p95) of the
+// endpoint's usual latency.
+func WithHedging(ctx context.Context, delay time.Duration) context.Context {
+	return context.WithValue(ctx, hedgingContextKey{}, delay)
+}
+
+// hedgingDelay returns the delay set with WithHedging, if any.
+func hedgingDelay(ctx context.Context) (time.Duration, bool) {
+	delay, found := ctx.Value(hedgingContextKey{}).(time.Duration)
+	return delay, found
+}
+
+// hedgeResult is the outcome of one hedged request.
+type hedgeResult struct {
+	body []byte
+	err  error
+}
+
+// hedge calls request and, if it hasn't returned after delay, calls it
+// again.  The first success is returned and the other call is cancelled.
+// If the first call fails before the delay, its error is returned without
+// hedging; otherwise the last error is returned when both calls fail.
+func hedge(ctx context.Context, delay time.Duration, request func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	launch := func() {
+		go func() {
+			body, err := request(ctx)
+			results <- hedgeResult{body: body, err: err}
+		}()
+	}
+
+	launch()
+	launched := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var lastErr error
+	for received := 0; received < launched; {
+		select {
+		case <-timer.C:
+			if launched == 1 {
+				launch()
+				launched++
+			}
+		case result := <-results:
+			received++
+			if result.err == nil {
+				return result.body, nil
+			}
+			lastErr = result.err
+		}
+	}
+
+	return make([]byte, 0), lastErr
+}
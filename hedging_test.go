@@ -0,0 +1,56 @@
+package strainapiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request stalls; the hedged one answers right away
+		if atomic.AddInt32(&requests, 1) == 1 {
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Write([]byte("[\"Earthy\"]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy))
+
+	start := time.Now()
+	flavors, err := client.ListAllFlavorsContext(WithHedging(context.Background(), 20*time.Millisecond))
+	elapsed := time.Since(start)
+
+	if err != nil || len(flavors) != 1 {
+		t.Errorf("Expected the hedged request's flavors but got %v and %v", flavors, err)
+	}
+
+	if elapsed > time.Second {
+		t.Errorf("Expected the hedged request to cut latency but took %s", elapsed)
+	}
+
+	if count := atomic.LoadInt32(&requests); count != 2 {
+		t.Errorf("Expected 2 requests but got %d", count)
+	}
+}
+
+func TestHedgeNotSentForFastResponses(t *testing.T) {
+	var requests int32
+	body, err := hedge(context.Background(), time.Second, func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&requests, 1)
+		return []byte("ok"), nil
+	})
+
+	if err != nil || string(body) != "ok" || requests != 1 {
+		t.Errorf("Expected a single request returning ok but got %d requests, %s, and %v", requests, body, err)
+	}
+}
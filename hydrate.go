@@ -0,0 +1,149 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// HydrateStrain fills in whichever of the description, flavors, and effects
+// of strain are missing (an empty description, or nil flavors or effects),
+// fetching them concurrently by strain.ID.  A strain with no description
+// keeps an empty one.  If client is also a ContextClient, ctx is passed to
+// its calls.  strain is only updated if every fetch succeeds.
+func HydrateStrain(ctx context.Context, client Client, strain *Strain) error {
+	return hydrateStrain(ctx, client, strain)
+}
+
+// HydrateSearchResult returns the strain for result, a name search result,
+// with its flavors and effects (and its description, if the search didn't
+// include one) fetched as by HydrateStrain.
+func HydrateSearchResult(ctx context.Context, client Client, result SearchStrainsByNameResult) (Strain, error) {
+	strain := Strain{Name: result.Name, ID: result.ID, Description: result.Description, Race: result.Race}
+	if err := hydrateStrain(ctx, client, &strain); err != nil {
+		return Strain{}, err
+	}
+
+	return strain, nil
+}
+
+// HydrateStrains returns a copy of strains with each strain hydrated as by
+// HydrateStrain, with at most concurrency strains being hydrated at once.  It
+// stops at the first error.
+func HydrateStrains(ctx context.Context, client Client, strains ListAllStrainsResult, concurrency int) (ListAllStrainsResult, error) {
+	return hydrateStrains(ctx, client, strains, concurrency)
+}
+
+// hydrateStrains returns a copy of strains with each strain hydrated by
+// hydrateStrain, with at most concurrency strains being hydrated at once.
+// It stops at the first error.
+func hydrateStrains(ctx context.Context, client Client, strains ListAllStrainsResult, concurrency int) (ListAllStrainsResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hydrated := make(ListAllStrainsResult)
+	var mutex sync.Mutex
+	var firstErr error
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for name, strain := range strains {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(name string, strain Strain) {
+			defer func() { <-slots }()
+			defer wg.Done()
+
+			err := hydrateStrain(ctx, client, &strain)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			hydrated[name] = strain
+		}(name, strain)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return hydrated, nil
+}
+
+// hydrateStrain implements HydrateStrain.
+func hydrateStrain(ctx context.Context, client Client, strain *Strain) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	contextClient, hasContext := client.(ContextClient)
+
+	description, flavors, effects := strain.Description, strain.Flavors, strain.Effects
+	var descriptionErr, flavorsErr, effectsErr error
+	var wg sync.WaitGroup
+
+	if strain.Description == "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if hasContext {
+				description, descriptionErr = contextClient.GetStrainDescriptionByStrainIDContext(ctx, strain.ID)
+			} else {
+				description, descriptionErr = client.GetStrainDescriptionByStrainID(strain.ID)
+			}
+
+			if errors.Is(descriptionErr, ErrNotFound) {
+				descriptionErr = nil
+			}
+		}()
+	}
+
+	if strain.Flavors == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if hasContext {
+				flavors, flavorsErr = contextClient.GetStrainFlavorsByStrainIDContext(ctx, strain.ID)
+			} else {
+				flavors, flavorsErr = client.GetStrainFlavorsByStrainID(strain.ID)
+			}
+		}()
+	}
+
+	if strain.Effects == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var byType EffectsByEffectType
+			if hasContext {
+				byType, effectsErr = contextClient.GetStrainEffectsByStrainIDContext(ctx, strain.ID)
+			} else {
+				byType, effectsErr = client.GetStrainEffectsByStrainID(strain.ID)
+			}
+			effects = effectNamesByType(byType)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range []error{descriptionErr, flavorsErr, effectsErr} {
+		if err != nil {
+			return fmt.Errorf("Problem hydrating strain %s: %w", strain.Name, err)
+		}
+	}
+
+	strain.Description, strain.Flavors, strain.Effects = description, flavors, effects
+	return nil
+}
@@ -0,0 +1,65 @@
+package strainapiclient
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHydrateStrainFillsMissingFields(t *testing.T) {
+	calls := make(chan string, 3)
+	client := newLookupTestClient()
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		calls <- path
+		if strings.Contains(path, "/strains/data/flavors/1") {
+			return []byte("[\"Berry\"]"), nil
+		}
+		return []byte("{\"positive\": [\"Happy\"]}"), nil
+	})
+
+	strain := Strain{Name: "Blue Dream", ID: 1, Description: "Sweet"}
+	if err := HydrateStrain(context.Background(), client, &strain); err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	expected := Strain{Name: "Blue Dream", ID: 1, Description: "Sweet", Flavors: []Flavor{"Berry"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}}
+	if !reflect.DeepEqual(strain, expected) {
+		t.Errorf("Expected %v but got %v", expected, strain)
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("Expected only flavors and effects to be fetched but got %d calls", len(calls))
+	}
+}
+
+func TestHydrateStrains(t *testing.T) {
+	strains := ListAllStrainsResult{"Blue Dream": {Name: "Blue Dream", ID: 1}}
+
+	hydrated, err := HydrateStrains(context.Background(), newLookupTestClient(), strains, 2)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if hydrated["Blue Dream"].Description != "Sweet" {
+		t.Errorf("Expected the description to be hydrated but got '%s'", hydrated["Blue Dream"].Description)
+	}
+
+	if strains["Blue Dream"].Description != "" {
+		t.Errorf("Expected the original strains to be left alone but got '%s'", strains["Blue Dream"].Description)
+	}
+}
+
+func TestHydrateSearchResult(t *testing.T) {
+	result := SearchStrainsByNameResult{Name: "Blue Dream", ID: 1, Race: RaceHybrid}
+
+	strain, err := HydrateSearchResult(context.Background(), newLookupTestClient(), result)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if strain.Description != "Sweet" || strain.Race != RaceHybrid || len(strain.Flavors) != 1 || len(strain.Effects) != 1 {
+		t.Errorf("Expected a hydrated Blue Dream but got %v", strain)
+	}
+}
@@ -0,0 +1,160 @@
+package strainapiclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HydrationResult is one strain fetched by a Hydrator, or the error
+// fetching it.
+type HydrationResult struct {
+	ID     int
+	Strain Strain
+	Err    error
+}
+
+// HydrationProgress is passed to a Hydrator's progress func after each
+// strain: how many strains have finished, how many of those failed, and the
+// result of the latest one.
+type HydrationProgress struct {
+	Completed int
+	Failed    int
+	Last      HydrationResult
+}
+
+// Hydrator fetches strains by ID with GetStrainByID through a pool of
+// workers, optionally rate limited, for enriching the strains from
+// ListAllStrains without hand-rolling the concurrency.  Configure it before
+// calling Run.
+type Hydrator struct {
+	client      Client
+	concurrency int
+
+	mutex    sync.Mutex
+	interval time.Duration
+	progress func(HydrationProgress)
+}
+
+// NewHydrator creates a new Hydrator fetching strains from client with
+// concurrency workers.  Values below 1 are treated as 1.
+func NewHydrator(client Client, concurrency int) *Hydrator {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Hydrator{client: client, concurrency: concurrency}
+}
+
+// SetRateLimit limits the Hydrator to perSecond strains a second across all
+// workers (each strain is three calls) and returns the previous limit.  A
+// limit of 0 or less removes it.
+func (h *Hydrator) SetRateLimit(perSecond float64) float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	current := 0.0
+	if h.interval > 0 {
+		current = float64(time.Second) / float64(h.interval)
+	}
+
+	h.interval = 0
+	if perSecond > 0 {
+		h.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return current
+}
+
+// SetProgressFunc sets the func called after each strain is fetched and
+// returns the previous one.  It is called from the workers, one call at a
+// time.
+func (h *Hydrator) SetProgressFunc(f func(HydrationProgress)) func(HydrationProgress) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	current := h.progress
+	h.progress = f
+	return current
+}
+
+// Run fetches each strain ID received from ids and sends its result on the
+// returned channel, in the order they finish.  The channel is closed once
+// ids is closed and every strain has been fetched, or once ctx is done.
+// Callers must keep reading the channel.
+func (h *Hydrator) Run(ctx context.Context, ids <-chan int) <-chan HydrationResult {
+	h.mutex.Lock()
+	interval, progress := h.interval, h.progress
+	h.mutex.Unlock()
+
+	results := make(chan HydrationResult, h.concurrency)
+
+	var throttle <-chan time.Time
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		throttle = ticker.C
+	}
+
+	var progressMutex sync.Mutex
+	state := HydrationProgress{}
+
+	var workers sync.WaitGroup
+	for worker := 0; worker < h.concurrency; worker++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for {
+				var id int
+				var ok bool
+				select {
+				case id, ok = <-ids:
+				case <-ctx.Done():
+					return
+				}
+				if !ok {
+					return
+				}
+
+				if throttle != nil {
+					select {
+					case <-throttle:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				strain, err := GetStrainByID(h.client, id)
+				result := HydrationResult{ID: id, Strain: strain, Err: err}
+
+				if progress != nil {
+					progressMutex.Lock()
+					state.Completed++
+					if err != nil {
+						state.Failed++
+					}
+					state.Last = result
+					progress(state)
+					progressMutex.Unlock()
+				}
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(results)
+	}()
+
+	return results
+}
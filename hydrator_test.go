@@ -0,0 +1,79 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHydratorRun(t *testing.T) {
+	hydrator := NewHydrator(newLookupTestClient(), 2)
+
+	progress := make([]HydrationProgress, 0)
+	hydrator.SetProgressFunc(func(p HydrationProgress) { progress = append(progress, p) })
+
+	ids := make(chan int, 3)
+	ids <- 1
+	ids <- 2
+	ids <- 1
+	close(ids)
+
+	found, failed := 0, 0
+	for result := range hydrator.Run(context.Background(), ids) {
+		switch {
+		case result.Err == nil && result.Strain.Description == "Sweet":
+			found++
+		case errors.Is(result.Err, ErrNotFound) && result.ID == 2:
+			failed++
+		default:
+			t.Errorf("Unexpected result %+v", result)
+		}
+	}
+
+	if found != 2 || failed != 1 {
+		t.Errorf("Expected 2 strains and 1 failure but got %d and %d", found, failed)
+	}
+
+	if len(progress) != 3 || progress[2].Completed != 3 || progress[2].Failed != 1 {
+		t.Errorf("Expected 3 progress reports ending at 3 completed, 1 failed but got %+v", progress)
+	}
+}
+
+func TestHydratorRateLimit(t *testing.T) {
+	hydrator := NewHydrator(newLookupTestClient(), 4)
+	if previous := hydrator.SetRateLimit(50); previous != 0 {
+		t.Errorf("Expected no previous limit but got %f", previous)
+	}
+
+	ids := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		ids <- 1
+	}
+	close(ids)
+
+	start := time.Now()
+	for range hydrator.Run(context.Background(), ids) {
+	}
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected 5 strains at 50 a second to take at least 80ms but took %s", elapsed)
+	}
+}
+
+func TestHydratorStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := make(chan int)
+
+	results := NewHydrator(newLookupTestClient(), 2).Run(ctx, ids)
+	cancel()
+
+	select {
+	case _, open := <-results:
+		if open {
+			t.Error("Expected no results after cancelling")
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the results channel to close after cancelling")
+	}
+}
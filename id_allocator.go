@@ -0,0 +1,98 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrIDConflict is returned when a locally-added strain's ID or name is
+// already used by another strain.
+var ErrIDConflict = newCodedError(CodeIDConflict, "Strain ID or name already in use")
+
+// IsLocalStrainID reports whether id belongs to a strain added locally
+// rather than one from the API.  The API's IDs are positive, so local
+// strains use negative IDs; 0 is never a valid ID.
+func IsLocalStrainID(id int) bool {
+	return id < 0
+}
+
+// IDAllocator allocates IDs for strains added with
+// FileBackedClient.AddLocalStrain.  IDs it returns must satisfy
+// IsLocalStrainID so they never collide with strains from the API.
+type IDAllocator interface {
+	AllocateID() int
+}
+
+// NegativeIDAllocator is the default IDAllocator, counting down from -1.
+// It is safe for concurrent use.
+type NegativeIDAllocator struct {
+	mutex sync.Mutex
+	next  int
+}
+
+// NewNegativeIDAllocator creates a new NegativeIDAllocator whose first ID
+// is below every local ID already used in strains.
+func NewNegativeIDAllocator(strains ListAllStrainsResult) *NegativeIDAllocator {
+	next := -1
+	for _, strain := range strains {
+		if strain.ID <= next {
+			next = strain.ID - 1
+		}
+	}
+
+	return &NegativeIDAllocator{next: next}
+}
+
+// AllocateID implements IDAllocator.
+func (a *NegativeIDAllocator) AllocateID() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	id := a.next
+	a.next--
+	return id
+}
+
+// SetIDAllocator sets the IDAllocator used by AddLocalStrain and returns
+// the previous one, which is nil until AddLocalStrain first creates a
+// NegativeIDAllocator.
+func (c *FileBackedClient) SetIDAllocator(allocator IDAllocator) IDAllocator {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	current := c.allocator
+	c.allocator = allocator
+	return current
+}
+
+// AddLocalStrain adds a custom strain to the data the client serves, with
+// an ID from the client's IDAllocator, and returns it as stored.  Any ID
+// already set on strain is replaced.  An error wrapping ErrIDConflict is
+// returned if the strain has no name, its name is already used, or the
+// allocator returns an ID that isn't local or is already used.
+func (c *FileBackedClient) AddLocalStrain(strain Strain) (Strain, error) {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	if strain.Name == "" {
+		return Strain{}, fmt.Errorf("Local strains must have a name: %w", ErrIDConflict)
+	}
+	if _, found := c.snapshot.Strains[strain.Name]; found {
+		return Strain{}, fmt.Errorf("A strain is already named %s: %w", strain.Name, ErrIDConflict)
+	}
+
+	if c.allocator == nil {
+		c.allocator = NewNegativeIDAllocator(c.snapshot.Strains)
+	}
+
+	strain.ID = c.allocator.AllocateID()
+	if !IsLocalStrainID(strain.ID) {
+		return Strain{}, fmt.Errorf("Allocated ID %d is not a local strain ID: %w", strain.ID, ErrIDConflict)
+	}
+	if _, found := c.byID[strain.ID]; found {
+		return Strain{}, fmt.Errorf("Allocated ID %d is already used: %w", strain.ID, ErrIDConflict)
+	}
+
+	c.putStrains(strain)
+	return strain, nil
+}
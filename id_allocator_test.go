@@ -0,0 +1,75 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+)
+
+type fixedIDAllocator int
+
+func (a fixedIDAllocator) AllocateID() int {
+	return int(a)
+}
+
+func TestAddLocalStrain(t *testing.T) {
+	client := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{
+		"Afpak":  {Name: "Afpak", ID: 1, Race: RaceHybrid},
+		"Custom": {Name: "Custom", ID: -3, Race: RaceIndica},
+	}})
+
+	strain, err := client.AddLocalStrain(Strain{Name: "House Blend", ID: 1, Race: RaceHybrid, Description: "Ours"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if strain.ID != -4 || !IsLocalStrainID(strain.ID) {
+		t.Errorf("Expected the next local ID -4 but got %d", strain.ID)
+	}
+
+	if description, _ := client.GetStrainDescriptionByStrainID(strain.ID); description != "Ours" {
+		t.Errorf("Expected the local strain to be served but got description '%s'", description)
+	}
+
+	if next, _ := client.AddLocalStrain(Strain{Name: "Second Blend", Race: RaceSativa}); next.ID != -5 {
+		t.Errorf("Expected IDs to keep counting down but got %d", next.ID)
+	}
+
+	if len(CheckIntegrity(client.snapshot.Strains)) != 0 {
+		t.Errorf("Expected local IDs to pass the integrity check but got %v", CheckIntegrity(client.snapshot.Strains))
+	}
+}
+
+func TestAddLocalStrainConflicts(t *testing.T) {
+	client := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{"Afpak": {Name: "Afpak", ID: 1}}})
+
+	if _, err := client.AddLocalStrain(Strain{Name: "Afpak"}); !errors.Is(err, ErrIDConflict) {
+		t.Errorf("Expected ErrIDConflict for a used name but got %v", err)
+	}
+
+	client.SetIDAllocator(fixedIDAllocator(1))
+	if _, err := client.AddLocalStrain(Strain{Name: "Upstream ID"}); !errors.Is(err, ErrIDConflict) {
+		t.Errorf("Expected ErrIDConflict for a positive ID but got %v", err)
+	}
+
+	client.SetIDAllocator(fixedIDAllocator(-1))
+	client.AddLocalStrain(Strain{Name: "First"})
+	if _, err := client.AddLocalStrain(Strain{Name: "Second"}); !errors.Is(err, ErrIDConflict) {
+		t.Errorf("Expected ErrIDConflict for a reused ID but got %v", err)
+	}
+}
+
+func TestCompositeClientKeepsLocalStrainsLocal(t *testing.T) {
+	local := NewSnapshotClient(Snapshot{Strains: make(ListAllStrainsResult)})
+	strain, _ := local.AddLocalStrain(Strain{Name: "House Blend"})
+
+	remote := NewDefaultClient("test-key")
+	remote.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		t.Errorf("Expected no API call for a local strain but got %s", path)
+		return []byte("{}"), nil
+	})
+
+	composite := NewCompositeClient(local, remote, false)
+	if _, err := composite.GetStrainDescriptionByStrainID(strain.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the local ErrNotFound but got %v", err)
+	}
+}
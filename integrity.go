@@ -0,0 +1,125 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IntegrityProblem identifies the kind of problem an IntegrityIssue reports.
+type IntegrityProblem string
+
+// The valid values of IntegrityProblem
+const (
+	// IntegrityEmptyName means the strain has no name
+	IntegrityEmptyName IntegrityProblem = "empty-name"
+	// IntegrityNameMismatch means the strain is keyed under a different name
+	IntegrityNameMismatch = "name-mismatch"
+	// IntegrityInvalidID means the strain's ID is 0
+	IntegrityInvalidID = "invalid-id"
+	// IntegrityDuplicateID means another strain has the same ID
+	IntegrityDuplicateID = "duplicate-id"
+	// IntegrityUnknownRace means the race isn't indica, sativa, or hybrid
+	IntegrityUnknownRace = "unknown-race"
+	// IntegrityUnknownEffectType means an effect isn't positive, negative, or medical
+	IntegrityUnknownEffectType = "unknown-effect-type"
+)
+
+// IntegrityIssue is a problem CheckIntegrity found with a strain.
+type IntegrityIssue struct {
+	Key     string           `json:"key"`
+	ID      int              `json:"id"`
+	Problem IntegrityProblem `json:"problem"`
+	Detail  string           `json:"detail"`
+}
+
+// CheckIntegrity scans a dataset for records with impossible values: empty
+// or mismatched names, invalid or duplicate IDs, unknown races, and unknown
+// effect types.  Strains without a Name, as in the raw API response, are
+// named by their key.  Issues are returned sorted by key.
+func CheckIntegrity(strains ListAllStrainsResult) []IntegrityIssue {
+	issues := make([]IntegrityIssue, 0)
+
+	keys := make([]string, 0)
+	for key := range strains {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keysByID := make(map[int]string)
+	for _, key := range keys {
+		strain := strains[key]
+		issue := func(problem IntegrityProblem, detail string) {
+			issues = append(issues, IntegrityIssue{Key: key, ID: strain.ID, Problem: problem, Detail: detail})
+		}
+
+		if strings.TrimSpace(key) == "" {
+			issue(IntegrityEmptyName, "strain has no name")
+		} else if strain.Name != "" && strain.Name != key {
+			issue(IntegrityNameMismatch, fmt.Sprintf("strain named '%s' is keyed as '%s'", strain.Name, key))
+		}
+
+		if strain.ID == 0 {
+			issue(IntegrityInvalidID, "ID 0 is not a valid ID")
+		} else if otherKey, found := keysByID[strain.ID]; found {
+			issue(IntegrityDuplicateID, fmt.Sprintf("ID %d is also used by '%s'", strain.ID, otherKey))
+		} else {
+			keysByID[strain.ID] = key
+		}
+
+		switch strain.Race {
+		case RaceIndica, RaceSativa, RaceHybrid:
+		default:
+			issue(IntegrityUnknownRace, fmt.Sprintf("race '%s' is not indica, sativa, or hybrid", strain.Race))
+		}
+
+		for effectType := range strain.Effects {
+			switch effectType {
+			case EffectTypePositive, EffectTypeNegative, EffectTypeMedical:
+			default:
+				issue(IntegrityUnknownEffectType, fmt.Sprintf("effect type '%s' is not positive, negative, or medical", effectType))
+			}
+		}
+	}
+
+	return issues
+}
+
+// RepairIntegrity returns a copy of strains with the issues found by
+// CheckIntegrity repaired: mismatched keys are rekeyed by name, unknown
+// effect types are dropped, and strains that can't be repaired (empty
+// names, invalid or duplicate IDs, unknown races) are removed.
+func RepairIntegrity(strains ListAllStrainsResult) ListAllStrainsResult {
+	unrepairable := make(map[string]bool)
+	for _, issue := range CheckIntegrity(strains) {
+		switch issue.Problem {
+		case IntegrityEmptyName, IntegrityInvalidID, IntegrityDuplicateID, IntegrityUnknownRace:
+			unrepairable[issue.Key] = true
+		}
+	}
+
+	repaired := make(ListAllStrainsResult)
+	for key, strain := range strains {
+		if unrepairable[key] {
+			continue
+		}
+
+		effects := make(map[EffectType][]string)
+		for _, effectType := range []EffectType{EffectTypePositive, EffectTypeNegative, EffectTypeMedical} {
+			if names, found := strain.Effects[effectType]; found {
+				effects[effectType] = names
+			}
+		}
+		strain.Effects = effects
+
+		if strain.Name == "" {
+			strain.Name = key
+		}
+		if _, taken := repaired[strain.Name]; taken {
+			continue
+		}
+		repaired[strain.Name] = strain
+	}
+
+	return repaired
+}
@@ -0,0 +1,51 @@
+package strainapiclient
+
+import (
+	"testing"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Afpak":      {Name: "Afpak", ID: 1, Race: RaceHybrid},
+		"Blue Dream": {Name: "Blue Dream", ID: 1, Race: RaceHybrid},
+		"Ghost":      {Name: "Ghost OG", ID: 3, Race: "ruderalis"},
+		"Haze":       {Name: "Haze", ID: 4, Race: RaceSativa, Effects: map[EffectType][]string{"weird": {"Odd"}}},
+	}
+
+	issues := CheckIntegrity(strains)
+
+	expected := []IntegrityProblem{IntegrityDuplicateID, IntegrityNameMismatch, IntegrityUnknownRace, IntegrityUnknownEffectType}
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues but got %+v", len(expected), issues)
+		return
+	}
+
+	for index, problem := range expected {
+		if issues[index].Problem != problem {
+			t.Errorf("Expected issue %d to be %s but got %+v", index, problem, issues[index])
+		}
+	}
+}
+
+func TestRepairIntegrity(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Afpak":      {Name: "Afpak", ID: 1, Race: RaceHybrid},
+		"Blue Dream": {Name: "Blue Dream", ID: 1, Race: RaceHybrid},
+		"Kush":       {Name: "OG Kush", ID: 3, Race: RaceIndica},
+		"Haze":       {Name: "Haze", ID: 4, Race: RaceSativa, Effects: map[EffectType][]string{"weird": {"Odd"}}},
+	}
+
+	repaired := RepairIntegrity(strains)
+
+	if issues := CheckIntegrity(repaired); len(issues) != 0 {
+		t.Errorf("Expected no issues after repairing but got %+v", issues)
+	}
+
+	if _, found := repaired["Blue Dream"]; found || len(repaired) != 3 {
+		t.Errorf("Expected the duplicate ID to be removed leaving 3 strains but got %v", repaired)
+	}
+
+	if _, found := repaired["OG Kush"]; !found {
+		t.Error("Expected OG Kush to be rekeyed by name")
+	}
+}
@@ -0,0 +1,151 @@
+package strainapiclient
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SKU is a stock-keeping unit a retailer carries for a strain.
+type SKU struct {
+	ID          string
+	Description string
+	Quantity    int
+}
+
+// Inventory reports what a retailer actually stocks, so API results can
+// be filtered down to strains that are available.
+type Inventory interface {
+	// InStock reports whether any SKU for the strain is in stock, along
+	// with all SKUs carried for it.
+	InStock(strainName string) (bool, []SKU)
+}
+
+// CSVInventory is an Inventory read from CSV with a header row and the
+// columns strain, sku, description, and quantity (in any order).  Strain
+// names are matched case-insensitively.
+type CSVInventory struct {
+	skusByStrain map[string][]SKU
+}
+
+// NewCSVInventory reads a CSVInventory from r.
+func NewCSVInventory(r io.Reader) (*CSVInventory, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Problem reading inventory CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("Inventory CSV has no header row")
+	}
+
+	columns := make(map[string]int)
+	for index, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+
+	for _, required := range []string{"strain", "sku", "quantity"} {
+		if _, found := columns[required]; !found {
+			return nil, fmt.Errorf("Inventory CSV is missing the '%s' column", required)
+		}
+	}
+
+	inventory := &CSVInventory{skusByStrain: make(map[string][]SKU)}
+
+	for line, record := range records[1:] {
+		quantity, err := strconv.Atoi(strings.TrimSpace(record[columns["quantity"]]))
+		if err != nil {
+			return nil, fmt.Errorf("Problem parsing quantity on line %d of inventory CSV: %w", line+2, err)
+		}
+
+		sku := SKU{ID: record[columns["sku"]], Quantity: quantity}
+		if index, found := columns["description"]; found {
+			sku.Description = record[index]
+		}
+
+		strain := strings.ToLower(strings.TrimSpace(record[columns["strain"]]))
+		inventory.skusByStrain[strain] = append(inventory.skusByStrain[strain], sku)
+	}
+
+	return inventory, nil
+}
+
+// InStock implements Inventory.
+func (i *CSVInventory) InStock(strainName string) (bool, []SKU) {
+	skus := i.skusByStrain[strings.ToLower(strings.TrimSpace(strainName))]
+
+	for _, sku := range skus {
+		if sku.Quantity > 0 {
+			return true, skus
+		}
+	}
+
+	return false, skus
+}
+
+// InStock returns only the strains that are in stock in inventory.
+func (r ListAllStrainsResult) InStock(inventory Inventory) ListAllStrainsResult {
+	stocked := make(ListAllStrainsResult)
+
+	for name, strain := range r {
+		if inStock, _ := inventory.InStock(name); inStock {
+			stocked[name] = strain
+		}
+	}
+
+	return stocked
+}
+
+// InStock returns only the results that are in stock in inventory.
+func (r SearchStrainsByNameResults) InStock(inventory Inventory) SearchStrainsByNameResults {
+	stocked := make(SearchStrainsByNameResults, 0)
+
+	for _, result := range r {
+		if inStock, _ := inventory.InStock(result.Name); inStock {
+			stocked = append(stocked, result)
+		}
+	}
+
+	return stocked
+}
+
+// InStock returns only the results that are in stock in inventory.
+func (r SearchStrainsByRaceResults) InStock(inventory Inventory) SearchStrainsByRaceResults {
+	stocked := make(SearchStrainsByRaceResults, 0)
+
+	for _, result := range r {
+		if inStock, _ := inventory.InStock(result.Name); inStock {
+			stocked = append(stocked, result)
+		}
+	}
+
+	return stocked
+}
+
+// InStock returns only the results that are in stock in inventory.
+func (r SearchStrainsByEffectNameResults) InStock(inventory Inventory) SearchStrainsByEffectNameResults {
+	stocked := make(SearchStrainsByEffectNameResults, 0)
+
+	for _, result := range r {
+		if inStock, _ := inventory.InStock(result.Name); inStock {
+			stocked = append(stocked, result)
+		}
+	}
+
+	return stocked
+}
+
+// InStock returns only the results that are in stock in inventory.
+func (r SearchStrainsByFlavorResults) InStock(inventory Inventory) SearchStrainsByFlavorResults {
+	stocked := make(SearchStrainsByFlavorResults, 0)
+
+	for _, result := range r {
+		if inStock, _ := inventory.InStock(result.Name); inStock {
+			stocked = append(stocked, result)
+		}
+	}
+
+	return stocked
+}
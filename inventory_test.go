@@ -0,0 +1,39 @@
+package strainapiclient
+
+import (
+	"strings"
+	"testing"
+)
+
+const testInventoryCSV = `strain,sku,description,quantity
+Afpak,AFP-1,Afpak 3.5g,4
+afpak,AFP-2,Afpak 7g,0
+Blue Dream,BD-1,Blue Dream 3.5g,0
+`
+
+func TestCSVInventory(t *testing.T) {
+	inventory, err := NewCSVInventory(strings.NewReader(testInventoryCSV))
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+		return
+	}
+
+	if inStock, skus := inventory.InStock("AFPAK"); !inStock || len(skus) != 2 {
+		t.Errorf("Expected Afpak to be in stock with 2 SKUs; got %t and %v", inStock, skus)
+	}
+
+	if inStock, skus := inventory.InStock("Blue Dream"); inStock || len(skus) != 1 {
+		t.Errorf("Expected Blue Dream to be out of stock with 1 SKU; got %t and %v", inStock, skus)
+	}
+
+	results := SearchStrainsByRaceResults{{Name: "Afpak"}, {Name: "Blue Dream"}, {Name: "Unknown"}}
+	if stocked := results.InStock(inventory); len(stocked) != 1 || stocked[0].Name != "Afpak" {
+		t.Errorf("Expected only Afpak to be in stock; got %v", stocked)
+	}
+}
+
+func TestCSVInventoryMissingColumn(t *testing.T) {
+	if _, err := NewCSVInventory(strings.NewReader("strain,sku\nAfpak,AFP-1\n")); err == nil {
+		t.Error("Expected an error for a missing quantity column but got nil")
+	}
+}
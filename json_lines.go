@@ -0,0 +1,89 @@
+package strainapiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ExportJSONLines streams every strain to w as JSON Lines (one Strain per
+// line, in name order), for piping into jq, BigQuery loads, or log
+// pipelines.  Strains are hydrated on the fly: a missing description,
+// flavors, or effects are fetched with the GetStrain*ByStrainID calls, with
+// at most concurrency strains being hydrated at once.  A strain with no
+// description is written without one.
+//
+// If client is also a ContextClient, ctx is passed to its calls; otherwise
+// ctx is checked between strains.  The export stops at the first error.
+func ExportJSONLines(ctx context.Context, client Client, w io.Writer, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return fmt.Errorf("Problem listing strains to export: %w", err)
+	}
+
+	names := make([]string, 0)
+	for name := range strains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type hydrated struct {
+		strain Strain
+		err    error
+	}
+
+	// Each strain gets its own result channel so lines are written in
+	// order; a slot is only freed once its line is written, which bounds
+	// the strains hydrated or waiting to be written to concurrency.
+	results := make([]chan hydrated, len(names))
+	for index := range names {
+		results[index] = make(chan hydrated, 1)
+	}
+	slots := make(chan struct{}, concurrency)
+
+	go func() {
+		for index, name := range names {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(result chan<- hydrated, strain Strain) {
+				err := hydrateStrain(ctx, client, &strain)
+				result <- hydrated{strain: strain, err: err}
+			}(results[index], strains[name])
+		}
+	}()
+
+	encoder := json.NewEncoder(w)
+	for index := range names {
+		var result hydrated
+		select {
+		case result = <-results[index]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if result.err != nil {
+			return result.err
+		}
+
+		if err := encoder.Encode(result.strain); err != nil {
+			return fmt.Errorf("Problem writing strain %s: %w", result.strain.Name, err)
+		}
+
+		<-slots
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package strainapiclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExportJSONLines(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if strings.HasSuffix(path, "/strains/search/all") {
+			return []byte(`{
+				"Blue Dream": {"id": 2, "race": "hybrid", "flavors": ["Sweet"], "effects": {"positive": ["Happy"]}},
+				"Afpak": {"id": 1, "race": "hybrid", "flavors": ["Earthy"], "effects": {"positive": ["Relaxed"]}},
+				"Sour Diesel": {"id": 4, "race": "sativa", "flavors": ["Diesel"], "effects": {"positive": ["Energetic"]}}
+			}`), nil
+		}
+
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if strings.HasSuffix(path, "/strains/data/desc/4") {
+			return []byte(`{"desc": ""}`), nil
+		}
+		return []byte(`{"desc": "Described"}`), nil
+	})
+
+	var buffer bytes.Buffer
+	if err := ExportJSONLines(context.Background(), client, &buffer, 2); err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	names := make([]string, 0)
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		strain := Strain{}
+		if err := json.Unmarshal(scanner.Bytes(), &strain); err != nil {
+			t.Errorf("Problem parsing line %s: %s", scanner.Text(), err)
+		}
+		names = append(names, strain.Name)
+
+		if expected := strain.ID != 4; (strain.Description == "Described") != expected {
+			t.Errorf("Unexpected description for %s: '%s'", strain.Name, strain.Description)
+		}
+	}
+
+	if strings.Join(names, ",") != "Afpak,Blue Dream,Sour Diesel" {
+		t.Errorf("Expected strains in name order but got %v", names)
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 strains hydrated at once but saw %d", maxInFlight)
+	}
+}
@@ -0,0 +1,256 @@
+// Package loadtest replays realistic query mixes against a strainapiclient.Client
+// and reports throughput and latency percentiles.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+// Operation is a single kind of call made during a load test.  Run is
+// given a per-worker *rand.Rand to pick its parameters with.
+type Operation struct {
+	Name   string
+	Weight int
+	Run    func(client strainapiclient.Client, r *rand.Rand) error
+}
+
+// Mix is the set of Operations a load test picks from, in proportion
+// to their Weight.
+type Mix []Operation
+
+var searchNames = []string{"Af", "Kush", "OG", "Haze", "Blue", "Sour", "Purple", "Lemon"}
+var searchEffects = []string{"Relaxed", "Happy", "Euphoric", "Uplifted", "Sleepy", "Creative"}
+var searchFlavors = []strainapiclient.Flavor{"Earthy", "Sweet", "Citrus", "Pine", "Berry", "Diesel"}
+var searchRaces = []strainapiclient.Race{strainapiclient.RaceIndica, strainapiclient.RaceSativa, strainapiclient.RaceHybrid}
+
+// SearchHeavyMix returns a Mix dominated by the search endpoints, like an
+// application backing a search UI.
+func SearchHeavyMix() Mix {
+	return Mix{
+		{Name: "SearchStrainsByName", Weight: 40, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.SearchStrainsByName(searchNames[r.Intn(len(searchNames))])
+			return err
+		}},
+		{Name: "SearchStrainsByEffectName", Weight: 20, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.SearchStrainsByEffectName(searchEffects[r.Intn(len(searchEffects))])
+			return err
+		}},
+		{Name: "SearchStrainsByFlavor", Weight: 20, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.SearchStrainsByFlavor(searchFlavors[r.Intn(len(searchFlavors))])
+			return err
+		}},
+		{Name: "SearchStrainsByRace", Weight: 10, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.SearchStrainsByRace(searchRaces[r.Intn(len(searchRaces))])
+			return err
+		}},
+		{Name: "GetStrainDescriptionByStrainID", Weight: 10, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.GetStrainDescriptionByStrainID(1 + r.Intn(maxStrainID))
+			return err
+		}},
+	}
+}
+
+// maxStrainID is the highest strain ID the hydrate operations request.
+const maxStrainID int = 1970
+
+// HydrateHeavyMix returns a Mix dominated by the per-strain data endpoints,
+// like a job filling in details for many strains.
+func HydrateHeavyMix() Mix {
+	return Mix{
+		{Name: "GetStrainDescriptionByStrainID", Weight: 30, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.GetStrainDescriptionByStrainID(1 + r.Intn(maxStrainID))
+			return err
+		}},
+		{Name: "GetStrainFlavorsByStrainID", Weight: 30, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.GetStrainFlavorsByStrainID(1 + r.Intn(maxStrainID))
+			return err
+		}},
+		{Name: "GetStrainEffectsByStrainID", Weight: 30, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.GetStrainEffectsByStrainID(1 + r.Intn(maxStrainID))
+			return err
+		}},
+		{Name: "SearchStrainsByName", Weight: 10, Run: func(client strainapiclient.Client, r *rand.Rand) error {
+			_, err := client.SearchStrainsByName(searchNames[r.Intn(len(searchNames))])
+			return err
+		}},
+	}
+}
+
+// ErrInvalidConfig is returned by Run when a Config can't be run.
+var ErrInvalidConfig = errors.New("invalid load test config")
+
+// Config controls a load test run.  The run stops when Duration has passed
+// or Requests calls have been made, whichever comes first; at least one of
+// them must be set.
+type Config struct {
+	Mix         Mix
+	Concurrency int
+	Duration    time.Duration
+	Requests    int
+	Seed        int64
+}
+
+// LatencyReport summarizes the latencies of a set of calls.
+type LatencyReport struct {
+	Requests int
+	Errors   int
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+// Report is the result of a load test run.
+type Report struct {
+	LatencyReport
+	Elapsed     time.Duration
+	Throughput  float64
+	ByOperation map[string]LatencyReport
+}
+
+type sample struct {
+	operation string
+	latency   time.Duration
+	err       error
+}
+
+// Run executes a load test against client and returns its Report.  It
+// returns ErrInvalidConfig when neither Duration nor Requests is set.
+func Run(ctx context.Context, client strainapiclient.Client, config Config) (Report, error) {
+	if config.Duration <= 0 && config.Requests <= 0 {
+		return Report{ByOperation: make(map[string]LatencyReport)}, fmt.Errorf("Duration or Requests must be positive: %w", ErrInvalidConfig)
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+
+	totalWeight := 0
+	for _, operation := range config.Mix {
+		totalWeight += operation.Weight
+	}
+
+	remaining := config.Requests
+	var remainingMutex sync.Mutex
+	takeRequest := func() bool {
+		if config.Requests <= 0 {
+			return true
+		}
+		remainingMutex.Lock()
+		defer remainingMutex.Unlock()
+		if remaining == 0 {
+			return false
+		}
+		remaining--
+		return true
+	}
+
+	samples := make(chan sample, config.Concurrency)
+	var workers sync.WaitGroup
+	started := time.Now()
+
+	for worker := 0; worker < config.Concurrency; worker++ {
+		workers.Add(1)
+		go func(r *rand.Rand) {
+			defer workers.Done()
+
+			for ctx.Err() == nil && totalWeight > 0 && takeRequest() {
+				operation := pickOperation(config.Mix, totalWeight, r)
+				start := time.Now()
+				err := operation.Run(client, r)
+				samples <- sample{operation: operation.Name, latency: time.Since(start), err: err}
+			}
+		}(rand.New(rand.NewSource(config.Seed + int64(worker))))
+	}
+
+	go func() {
+		workers.Wait()
+		close(samples)
+	}()
+
+	all := make([]sample, 0)
+	for s := range samples {
+		all = append(all, s)
+	}
+
+	elapsed := time.Since(started)
+	report := Report{
+		LatencyReport: summarize(all),
+		Elapsed:       elapsed,
+		ByOperation:   make(map[string]LatencyReport),
+	}
+
+	if elapsed > 0 {
+		report.Throughput = float64(len(all)) / elapsed.Seconds()
+	}
+
+	byOperation := make(map[string][]sample)
+	for _, s := range all {
+		byOperation[s.operation] = append(byOperation[s.operation], s)
+	}
+
+	for name, operationSamples := range byOperation {
+		report.ByOperation[name] = summarize(operationSamples)
+	}
+
+	return report, nil
+}
+
+func pickOperation(mix Mix, totalWeight int, r *rand.Rand) Operation {
+	n := r.Intn(totalWeight)
+	for _, operation := range mix {
+		if n < operation.Weight {
+			return operation
+		}
+		n -= operation.Weight
+	}
+
+	return mix[len(mix)-1]
+}
+
+func summarize(samples []sample) LatencyReport {
+	report := LatencyReport{Requests: len(samples)}
+	if len(samples) == 0 {
+		return report
+	}
+
+	latencies := make([]time.Duration, len(samples))
+	for index, s := range samples {
+		latencies[index] = s.latency
+		if s.err != nil {
+			report.Errors++
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	report.P50 = percentile(latencies, 50)
+	report.P90 = percentile(latencies, 90)
+	report.P99 = percentile(latencies, 99)
+	report.Max = latencies[len(latencies)-1]
+
+	return report
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,54 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+func TestRunStopsAfterRequests(t *testing.T) {
+	client := strainapiclient.NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if strings.Contains(path, "/strains/data/desc/") {
+			return []byte("{\"desc\": \"A description\"}"), errors.New("mock failure")
+		}
+		return []byte("[]"), nil
+	})
+
+	expectedRequests := 100
+	report, err := Run(context.Background(), client, Config{
+		Mix:         SearchHeavyMix(),
+		Concurrency: 4,
+		Requests:    expectedRequests,
+		Seed:        1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if report.Requests != expectedRequests {
+		t.Errorf("Expected %d requests but got %d", expectedRequests, report.Requests)
+	}
+
+	descriptionReport := report.ByOperation["GetStrainDescriptionByStrainID"]
+	if report.Errors == 0 || report.Errors != descriptionReport.Errors || descriptionReport.Errors != descriptionReport.Requests {
+		t.Errorf("Expected only the description calls to fail; got %d errors overall and %v for descriptions", report.Errors, descriptionReport)
+	}
+
+	if report.P50 > report.P99 || report.P99 > report.Max {
+		t.Errorf("Expected ordered percentiles but got %v", report.LatencyReport)
+	}
+}
+
+func TestRunRejectsUnboundedConfig(t *testing.T) {
+	client := strainapiclient.NewDefaultClient("test-key")
+
+	_, err := Run(context.Background(), client, Config{Mix: SearchHeavyMix()})
+
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig but got: %v", err)
+	}
+}
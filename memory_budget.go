@@ -0,0 +1,220 @@
+package strainapiclient
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Rough per-item overheads, in bytes, used to estimate memory usage.
+const (
+	memoryEntryOverhead  int64 = 64
+	memoryStrainOverhead       = 128
+)
+
+// MemoryConsumer is something holding data in memory that can report its
+// approximate size and free some of it, such as a MemoryCache,
+// FileBackedClient, or StrainIndex.
+type MemoryConsumer interface {
+	// MemoryUsage returns the approximate number of bytes held.
+	MemoryUsage() int64
+	// Evict frees roughly bytes bytes, least valuable data first, and
+	// returns how many bytes it freed, which may be less (or zero).
+	Evict(bytes int64) int64
+}
+
+type memoryBudgetConsumer struct {
+	consumer MemoryConsumer
+	priority int
+}
+
+// MemoryBudget caps the memory used by the caches, indexes, and datasets
+// registered with it, for running in small containers.  When they use more
+// than the limit, Enforce asks them to evict data, lowest priority first.
+type MemoryBudget struct {
+	limit int64
+
+	mutex     sync.Mutex
+	consumers []memoryBudgetConsumer
+}
+
+// NewMemoryBudget creates a new MemoryBudget of limit bytes.
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{limit: limit, consumers: make([]memoryBudgetConsumer, 0)}
+}
+
+// Register adds consumer to the budget.  Consumers with a lower priority
+// are asked to evict first, so register what is cheapest to rebuild (such
+// as a cache) with the lowest priority.
+func (b *MemoryBudget) Register(consumer MemoryConsumer, priority int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.consumers = append(b.consumers, memoryBudgetConsumer{consumer: consumer, priority: priority})
+	sort.SliceStable(b.consumers, func(i, j int) bool { return b.consumers[i].priority < b.consumers[j].priority })
+}
+
+// Usage returns the approximate number of bytes used by every registered
+// consumer.
+func (b *MemoryBudget) Usage() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	usage := int64(0)
+	for _, registered := range b.consumers {
+		usage += registered.consumer.MemoryUsage()
+	}
+
+	return usage
+}
+
+// Enforce asks consumers to evict data, lowest priority first, until the
+// total usage is within the limit or every consumer has been asked.  It
+// returns the number of bytes freed.
+func (b *MemoryBudget) Enforce() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	usage := int64(0)
+	for _, registered := range b.consumers {
+		usage += registered.consumer.MemoryUsage()
+	}
+
+	freed := int64(0)
+	for _, registered := range b.consumers {
+		if usage-freed <= b.limit {
+			break
+		}
+		freed += registered.consumer.Evict(usage - freed - b.limit)
+	}
+
+	return freed
+}
+
+// Run calls Enforce every interval until ctx is done.
+func (b *MemoryBudget) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.Enforce()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// MemoryUsage implements MemoryConsumer.
+func (m *MemoryCache) MemoryUsage() int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	usage := int64(0)
+	for key, entry := range m.entries {
+		usage += memoryEntryOverhead + int64(len(key)+len(entry.value))
+	}
+
+	return usage
+}
+
+// Evict implements MemoryConsumer by removing expired entries and then the
+// entries closest to expiring.
+func (m *MemoryCache) Evict(bytes int64) int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	keys := make([]string, 0)
+	for key := range m.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m.entries[keys[i]].expiresAt.Before(m.entries[keys[j]].expiresAt) })
+
+	freed := int64(0)
+	for _, key := range keys {
+		if freed >= bytes {
+			break
+		}
+		freed += memoryEntryOverhead + int64(len(key)+len(m.entries[key].value))
+		delete(m.entries, key)
+	}
+
+	return freed
+}
+
+// strainMemoryUsage estimates the bytes held by strain.
+func strainMemoryUsage(strain Strain) int64 {
+	usage := memoryStrainOverhead + int64(len(strain.Name)+len(strain.Description)+len(strain.Race))
+
+	for _, flavor := range strain.Flavors {
+		usage += int64(len(flavor))
+	}
+	for _, names := range strain.Effects {
+		for _, name := range names {
+			usage += int64(len(name))
+		}
+	}
+
+	return usage
+}
+
+// MemoryUsage implements MemoryConsumer.
+func (c *FileBackedClient) MemoryUsage() int64 {
+	snapshot, _ := c.data()
+
+	usage := int64(0)
+	for _, strain := range snapshot.Strains {
+		usage += strainMemoryUsage(strain)
+	}
+
+	return usage
+}
+
+// Evict implements MemoryConsumer by dropping strain descriptions, the
+// bulk of the data and the cheapest to fetch again (for example through a
+// CompositeClient).  Strains themselves are never evicted, and evicting
+// isn't recorded in their history.
+func (c *FileBackedClient) Evict(bytes int64) int64 {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	freed := int64(0)
+	stripped := make([]Strain, 0)
+	for _, strain := range c.snapshot.Strains {
+		if freed >= bytes {
+			break
+		}
+		if strain.Description != "" {
+			freed += int64(len(strain.Description))
+			strain.Description = ""
+			stripped = append(stripped, strain)
+		}
+	}
+
+	c.storeStrains(stripped...)
+	return freed
+}
+
+// MemoryUsage implements MemoryConsumer.
+func (i *StrainIndex) MemoryUsage() int64 {
+	usage := int64(0)
+
+	for _, strain := range i.strains {
+		usage += strainMemoryUsage(strain)
+	}
+	for term, postings := range i.postings {
+		usage += memoryEntryOverhead + int64(len(term)) + int64(len(postings))*16
+	}
+
+	return usage
+}
+
+// Evict implements MemoryConsumer.  A StrainIndex is read-only, so it
+// never frees anything; register it so its usage counts towards the budget
+// and build a new one from less data to shrink it.
+func (i *StrainIndex) Evict(bytes int64) int64 {
+	return 0
+}
@@ -0,0 +1,76 @@
+package strainapiclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryBudgetEnforce(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("soon", make([]byte, 1000), time.Minute)
+	cache.Set("later", make([]byte, 1000), time.Hour)
+
+	dataset := NewSnapshotClient(newFakeSnapshot())
+	index := NewStrainIndex(newFakeSnapshot().Strains)
+
+	budget := NewMemoryBudget(0)
+	budget.Register(index, 2)
+	budget.Register(dataset, 1)
+	budget.Register(cache, 0)
+
+	datasetUsage := dataset.MemoryUsage()
+	budget.limit = budget.Usage() - 1500
+
+	if freed := budget.Enforce(); freed < 1500 {
+		t.Errorf("Expected at least 1500 bytes freed but got %d", freed)
+	}
+
+	if _, found, _ := cache.Get("later"); found {
+		t.Error("Expected both cache entries to be evicted")
+	}
+
+	if dataset.MemoryUsage() != datasetUsage {
+		t.Error("Expected the dataset to be left alone once the cache freed enough")
+	}
+
+	if budget.Usage() > budget.limit {
+		t.Errorf("Expected usage within %d but got %d", budget.limit, budget.Usage())
+	}
+}
+
+func TestMemoryCacheEvictsSoonestToExpire(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("soon", make([]byte, 100), time.Minute)
+	cache.Set("later", make([]byte, 100), time.Hour)
+
+	cache.Evict(1)
+
+	if _, found, _ := cache.Get("soon"); found {
+		t.Error("Expected the entry closest to expiring to be evicted")
+	}
+	if _, found, _ := cache.Get("later"); !found {
+		t.Error("Expected the later entry to be kept")
+	}
+}
+
+func TestFileBackedClientEvictsDescriptions(t *testing.T) {
+	client := NewSnapshotClient(newFakeSnapshot())
+	before := client.MemoryUsage()
+
+	freed := client.Evict(before)
+
+	if freed == 0 || client.MemoryUsage() != before-freed {
+		t.Errorf("Expected usage to drop by the %d bytes freed but went from %d to %d", freed, before, client.MemoryUsage())
+	}
+
+	strains, _ := client.ListAllStrains()
+	for name, strain := range strains {
+		if strain.Description != "" {
+			t.Errorf("Expected the description of %s to be evicted", name)
+		}
+	}
+
+	if len(strains) != len(newFakeSnapshot().Strains) {
+		t.Errorf("Expected every strain to be kept but got %d", len(strains))
+	}
+}
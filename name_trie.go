@@ -0,0 +1,110 @@
+package strainapiclient
+
+import (
+	"sort"
+	"strings"
+)
+
+// trieNode is a node of a StrainNameTrie.  names holds the strain names
+// whose indexed text ends at the node, with whether it was the start of
+// the name (rather than a later word).
+type trieNode struct {
+	children map[rune]*trieNode
+	names    map[string]bool
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode), names: make(map[string]bool)}
+}
+
+// StrainNameTrie suggests strain names for a typed prefix from a trie built
+// once over a strain list, so UIs can offer type-ahead without a request
+// per keystroke.  Prefixes match, ignoring case, the start of a name or of
+// any word in it, so "dre" suggests "Blue Dream".  A StrainNameTrie is
+// read-only once built, so it is safe for concurrent use.
+type StrainNameTrie struct {
+	root *trieNode
+}
+
+// NewStrainNameTrie builds a StrainNameTrie over the names of strains.
+func NewStrainNameTrie(strains ListAllStrainsResult) *StrainNameTrie {
+	trie := &StrainNameTrie{root: newTrieNode()}
+
+	for name := range strains {
+		lower := strings.ToLower(name)
+		words := strings.Fields(lower)
+
+		for i := range words {
+			trie.insert(strings.Join(words[i:], " "), name, i == 0)
+		}
+	}
+
+	return trie
+}
+
+// insert adds text to the trie, recording name at its last node.
+func (t *StrainNameTrie) insert(text string, name string, start bool) {
+	node := t.root
+
+	for _, r := range text {
+		child, found := node.children[r]
+		if !found {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+
+	node.names[name] = node.names[name] || start
+}
+
+// SuggestStrainNames returns up to limit strain names matching prefix.
+// Names that start with prefix come before names with a later word that
+// does, and each group is sorted by name.  A limit of zero or less returns
+// every match; an empty prefix returns none.
+func (t *StrainNameTrie) SuggestStrainNames(prefix string, limit int) []string {
+	suggestions := make([]string, 0)
+
+	prefix = strings.Join(strings.Fields(strings.ToLower(prefix)), " ")
+	if prefix == "" {
+		return suggestions
+	}
+
+	node := t.root
+	for _, r := range prefix {
+		if node = node.children[r]; node == nil {
+			return suggestions
+		}
+	}
+
+	matches := make(map[string]bool)
+	collectTrieNames(node, matches)
+
+	for name := range matches {
+		suggestions = append(suggestions, name)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if matches[suggestions[i]] != matches[suggestions[j]] {
+			return matches[suggestions[i]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// collectTrieNames adds every name at or below node to matches.
+func collectTrieNames(node *trieNode, matches map[string]bool) {
+	for name, start := range node.names {
+		matches[name] = matches[name] || start
+	}
+
+	for _, child := range node.children {
+		collectTrieNames(child, matches)
+	}
+}
@@ -0,0 +1,33 @@
+package strainapiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestStrainNames(t *testing.T) {
+	trie := NewStrainNameTrie(ListAllStrainsResult{
+		"Blue Dream":    {ID: 1},
+		"Blueberry":     {ID: 2},
+		"Dream Queen":   {ID: 3},
+		"Sour Diesel":   {ID: 4},
+		"Super Blue OG": {ID: 5},
+	})
+
+	for _, test := range []struct {
+		prefix   string
+		limit    int
+		expected []string
+	}{
+		{"blue", 0, []string{"Blue Dream", "Blueberry", "Super Blue OG"}},
+		{"BLUE", 2, []string{"Blue Dream", "Blueberry"}},
+		{"dre", 0, []string{"Dream Queen", "Blue Dream"}},
+		{"blue  dr", 0, []string{"Blue Dream"}},
+		{"kush", 0, []string{}},
+		{"", 0, []string{}},
+	} {
+		if actual := trie.SuggestStrainNames(test.prefix, test.limit); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected suggestions %v for '%s' but got %v", test.expected, test.prefix, actual)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"time"
+)
+
+// DefaultNegativeCacheTTLs returns the negative TTLs a new CachingClient
+// starts with.  They are short so strains added upstream show up quickly.
+func DefaultNegativeCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		CacheEndpointSearch:     5 * time.Minute,
+		CacheEndpointStrainData: 5 * time.Minute,
+	}
+}
+
+// SetNegativeCacheTTLs sets how long empty results and ErrNotFound errors
+// are cached for each CacheEndpoint, so repeatedly asking for a strain ID
+// that doesn't exist or a search that matches nothing doesn't hit the API
+// every time.  Endpoints with no TTL (or a TTL of zero) cache empty results
+// for their regular TTL and never cache ErrNotFound.  Pass nil to turn
+// negative caching off.  It returns the previous negative TTLs.
+func (c *CachingClient) SetNegativeCacheTTLs(ttls CacheTTLs) CacheTTLs {
+	c.negativeMutex.Lock()
+	defer c.negativeMutex.Unlock()
+
+	previous := c.negativeTTLs
+	c.negativeTTLs = ttls
+	return previous
+}
+
+// InvalidateNegative removes every negatively cached result, for example
+// after strains were added upstream.  Regular cached results are kept.
+func (c *CachingClient) InvalidateNegative() error {
+	c.negativeMutex.Lock()
+	keys := c.negativeKeys
+	c.negativeKeys = make(map[string]bool)
+	c.negativeMutex.Unlock()
+
+	var firstErr error
+	for key := range keys {
+		if err := c.cache.Delete(key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// negativeTTL returns the negative TTL for endpoint.
+func (c *CachingClient) negativeTTL(endpoint CacheEndpoint) time.Duration {
+	c.negativeMutex.Lock()
+	defer c.negativeMutex.Unlock()
+
+	return c.negativeTTLs[endpoint]
+}
+
+// setNegative caches a negative result for key and remembers the key so
+// InvalidateNegative can remove it.
+func (c *CachingClient) setNegative(key string, value []byte, ttl time.Duration) {
+	c.negativeMutex.Lock()
+	c.negativeKeys[key] = true
+	c.negativeMutex.Unlock()
+
+	c.cache.Set(key, value, ttl)
+}
+
+// notFoundCacheKey is the key an ErrNotFound result for key is cached under.
+func notFoundCacheKey(key string) string {
+	return "not-found/" + key
+}
+
+// isEmptyResultJSON reports whether resultJSON is an empty list, map, or
+// string.
+func isEmptyResultJSON(resultJSON []byte) bool {
+	for _, empty := range []string{"[]", "{}", "\"\"", "null"} {
+		if bytes.Equal(resultJSON, []byte(empty)) {
+			return true
+		}
+	}
+
+	return false
+}
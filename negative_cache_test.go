@@ -0,0 +1,86 @@
+package strainapiclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newNotFoundTestClient(calls map[string]int) *DefaultClient {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		calls[path[strings.Index(path, "test-key")+len("test-key"):]]++
+
+		if strings.Contains(path, "/strains/data/desc/") {
+			return []byte("{}"), nil
+		}
+
+		return []byte("[]"), nil
+	})
+
+	return client
+}
+
+func TestCachingClientCachesNotFound(t *testing.T) {
+	calls := make(map[string]int)
+	client := NewCachingClient(newNotFoundTestClient(calls), CacheTTLs{})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetStrainDescriptionByStrainID(999); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound but got %v", err)
+		}
+		client.SearchStrainsByName("Nothing")
+	}
+
+	if calls["/strains/data/desc/999"] != 1 || calls["/strains/search/name/Nothing"] != 1 {
+		t.Errorf("Expected 1 call each for the missing strain and the empty search but got %v", calls)
+	}
+}
+
+func TestCachingClientNegativeTTLExpires(t *testing.T) {
+	calls := make(map[string]int)
+	client := NewCachingClient(newNotFoundTestClient(calls), CacheTTLs{CacheEndpointSearch: time.Hour})
+
+	if previous := client.SetNegativeCacheTTLs(CacheTTLs{CacheEndpointSearch: 20 * time.Millisecond}); previous[CacheEndpointSearch] != 5*time.Minute {
+		t.Errorf("Expected the previous negative TTLs to be the defaults but got %v", previous)
+	}
+
+	client.SearchStrainsByName("Nothing")
+	client.SearchStrainsByName("Nothing")
+	time.Sleep(30 * time.Millisecond)
+	client.SearchStrainsByName("Nothing")
+
+	// Strain data has no negative TTL so not found is never cached
+	client.GetStrainDescriptionByStrainID(999)
+	client.GetStrainDescriptionByStrainID(999)
+
+	if calls["/strains/search/name/Nothing"] != 2 || calls["/strains/data/desc/999"] != 2 {
+		t.Errorf("Expected 2 calls each for the empty search and the missing strain but got %v", calls)
+	}
+}
+
+func TestCachingClientInvalidateNegative(t *testing.T) {
+	calls := make(map[string]int)
+	client := NewCachingClient(newNotFoundTestClient(calls), nil)
+
+	client.GetStrainDescriptionByStrainID(999)
+	client.SearchStrainsByName("Nothing")
+	client.ListAllEffects()
+
+	if err := client.InvalidateNegative(); err != nil {
+		t.Errorf("Expected no error invalidating but got: %s", err)
+	}
+
+	client.GetStrainDescriptionByStrainID(999)
+	client.SearchStrainsByName("Nothing")
+	client.ListAllEffects()
+
+	if calls["/strains/data/desc/999"] != 2 || calls["/strains/search/name/Nothing"] != 2 {
+		t.Errorf("Expected negative results to be fetched again but got %v", calls)
+	}
+
+	if calls["/searchdata/effects"] != 1 {
+		t.Errorf("Expected effects to stay cached but got %v", calls)
+	}
+}
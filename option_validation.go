@@ -0,0 +1,83 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidOptions is returned (wrapped) by NewValidatedClient and
+// DefaultClient.Validate when options are invalid or conflict.
+var ErrInvalidOptions = newCodedError(CodeInvalidOptions, "Invalid client options")
+
+// NewValidatedClient is NewClient, but returns an error wrapping
+// ErrInvalidOptions instead of a client when the options are invalid or
+// conflict with each other (see DefaultClient.Validate).
+func NewValidatedClient(apiKey string, opts ...Option) (*DefaultClient, error) {
+	client := NewClient(apiKey, opts...)
+
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Validate checks the client's configuration and returns an error wrapping
+// ErrInvalidOptions that lists every problem found, such as a base URL that
+// isn't an http(s) URL, a negative timeout, an impossible RetryPolicy or
+// AdaptiveTimeoutConfig, or WithTimeout combined with WithAdaptiveTimeout,
+// which would otherwise silently ignore the fixed timeout.
+func (c *DefaultClient) Validate() error {
+	problems := make([]string, 0)
+
+	if c.apiKey == "" {
+		problems = append(problems, "the API Key is empty")
+	}
+
+	if parsed, err := url.Parse(c.baseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		problems = append(problems, fmt.Sprintf("base URL '%s' is not an http or https URL", c.baseURL))
+	}
+
+	if c.timeout < 0 {
+		problems = append(problems, fmt.Sprintf("timeout %s is negative", c.timeout))
+	}
+
+	if policy := c.retryPolicy; policy.MaxAttempts > 1 {
+		if policy.BaseDelay < 0 || (policy.MaxDelay > 0 && policy.MaxDelay < policy.BaseDelay) {
+			problems = append(problems, fmt.Sprintf("retry delays must satisfy 0 <= BaseDelay (%s) <= MaxDelay (%s), unless MaxDelay is unset", policy.BaseDelay, policy.MaxDelay))
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			problems = append(problems, fmt.Sprintf("retry jitter %g is not between 0 and 1", policy.Jitter))
+		}
+	}
+
+	if c.adaptiveTimeouts != nil {
+		config := c.adaptiveTimeouts.config
+
+		if c.timeout > 0 {
+			problems = append(problems, "WithTimeout and WithAdaptiveTimeout both set the request timeout")
+		}
+		if c.httpClient.Timeout > 0 {
+			problems = append(problems, "the http.Client passed to WithHTTPClient has a Timeout, which caps WithAdaptiveTimeout")
+		}
+		if config.Percentile <= 0 || config.Percentile > 1 {
+			problems = append(problems, fmt.Sprintf("adaptive timeout percentile %g is not above 0 and at most 1", config.Percentile))
+		}
+		if config.Multiplier <= 0 {
+			problems = append(problems, fmt.Sprintf("adaptive timeout multiplier %g is not positive", config.Multiplier))
+		}
+		if config.Max <= 0 || config.Min > config.Max {
+			problems = append(problems, fmt.Sprintf("adaptive timeouts must satisfy Min (%s) <= Max (%s) and Max > 0", config.Min, config.Max))
+		}
+		if config.SampleSize < config.MinSamples {
+			problems = append(problems, fmt.Sprintf("adaptive timeout SampleSize %d is less than MinSamples %d", config.SampleSize, config.MinSamples))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidOptions, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package strainapiclient
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewValidatedClient(t *testing.T) {
+	client, err := NewValidatedClient("test-key", WithTimeout(time.Second), WithRetryPolicy(NoRetryPolicy))
+	if err != nil || client == nil {
+		t.Errorf("Expected a client and no error but got %v", err)
+	}
+
+	client, err = NewValidatedClient("test-key", WithAdaptiveTimeout(DefaultAdaptiveTimeoutConfig))
+	if err != nil || client == nil {
+		t.Errorf("Expected a client and no error but got %v", err)
+	}
+}
+
+func TestNewValidatedClientAllowsUncappedRetryDelay(t *testing.T) {
+	client, err := NewValidatedClient("test-key", WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond}))
+	if err != nil || client == nil {
+		t.Errorf("Expected a client and no error for an unset MaxDelay but got %v", err)
+	}
+}
+
+func TestNewValidatedClientRejectsConflicts(t *testing.T) {
+	client, err := NewValidatedClient("test-key",
+		WithTimeout(time.Second),
+		WithAdaptiveTimeout(DefaultAdaptiveTimeoutConfig),
+		WithHTTPClient(&http.Client{Timeout: time.Second}))
+
+	if client != nil || !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("Expected ErrInvalidOptions but got %v", err)
+	}
+
+	for _, expected := range []string{"WithTimeout and WithAdaptiveTimeout", "WithHTTPClient has a Timeout"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to mention '%s' but got: %s", expected, err)
+		}
+	}
+
+	if ErrorCode(err) != CodeInvalidOptions {
+		t.Errorf("Expected code %s but got %s", CodeInvalidOptions, ErrorCode(err))
+	}
+}
+
+func TestValidateRejectsInvalidValues(t *testing.T) {
+	client := NewClient("",
+		WithBaseURL("not a url"),
+		WithTimeout(-time.Second),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Millisecond, Jitter: 2}),
+		WithAdaptiveTimeout(AdaptiveTimeoutConfig{Percentile: 2, Multiplier: 0, Min: time.Minute, Max: time.Second, SampleSize: 1, MinSamples: 5}))
+
+	err := client.Validate()
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("Expected ErrInvalidOptions but got %v", err)
+	}
+
+	for _, expected := range []string{"API Key", "base URL", "negative", "retry delays", "jitter", "percentile", "multiplier", "Min", "SampleSize"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to mention '%s' but got: %s", expected, err)
+		}
+	}
+}
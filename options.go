@@ -0,0 +1,93 @@
+package strainapiclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a DefaultClient created with NewClient.
+type Option func(*DefaultClient)
+
+// WithTimeout sets the maximum time a single request to the API may take.
+// A timeout of zero (the default) means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *DefaultClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithBaseURL sets the base URL requests are made against (the API Key
+// and resource path are appended to it), for example a mirror, a caching
+// proxy, or an httptest.Server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *DefaultClient) {
+		c.SetBaseURL(baseURL)
+	}
+}
+
+// WithHTTPClient sets the *http.Client used to make requests to the API,
+// so you can supply your own transport, proxy settings, and connection pooling.
+// The same *http.Client is reused for every request.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *DefaultClient) {
+		c.SetHTTPClient(httpClient)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *DefaultClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy the default request handler uses
+// for transient failures.  Use NoRetryPolicy to disable retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *DefaultClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithConditionalRequests makes the default request handler remember the
+// ETag and Last-Modified values of responses, along with their bodies, in
+// cache and send If-None-Match/If-Modified-Since on later requests for the
+// same resource.  When the API answers 304 Not Modified the remembered body
+// is returned, so repeated calls such as ListAllStrains are cheap for both
+// sides.  Entries are keyed by resource path, without the API Key.  If cache
+// is nil a new MemoryCache is used; pass a FileCache to keep the values
+// across runs.
+func WithConditionalRequests(cache Cache) Option {
+	return func(c *DefaultClient) {
+		if cache == nil {
+			cache = NewMemoryCache()
+		}
+		c.conditionalCache = cache
+	}
+}
+
+// WithAliasRegistry makes name searches resolve street aliases (such as
+// "GSC" for "Girl Scout Cookies") through registry before calling the API.
+func WithAliasRegistry(registry *AliasRegistry) Option {
+	return func(c *DefaultClient) {
+		c.aliases = registry
+	}
+}
+
+// WithAdaptiveTimeout makes the timeout of each request follow the latency
+// recently observed for its endpoint, as described by config (see
+// DefaultAdaptiveTimeoutConfig), instead of a fixed WithTimeout.
+func WithAdaptiveTimeout(config AdaptiveTimeoutConfig) Option {
+	return func(c *DefaultClient) {
+		c.adaptiveTimeouts = newAdaptiveTimeouts(config)
+	}
+}
+
+// WithFaultInjector makes the default request handler pass each attempt
+// through injector, inside the RetryPolicy and after the attempt's timeout
+// is set, so injected errors are retried and injected latency can time out.
+func WithFaultInjector(injector *FaultInjector) Option {
+	return func(c *DefaultClient) {
+		c.faultInjector = injector
+	}
+}
@@ -0,0 +1,104 @@
+package strainapiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countingRoundTripper struct {
+	count int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	var actualPath, actualUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		actualUserAgent = r.UserAgent()
+		w.Write([]byte("[\"Earthy\"]"))
+	}))
+	defer server.Close()
+
+	transport := &countingRoundTripper{}
+	client := NewClient("test-key",
+		WithBaseURL(server.URL+"/"),
+		WithUserAgent("test-agent/1"),
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	flavors, err := client.ListAllFlavors()
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	if len(flavors) != 1 || actualPath != "/test-key/searchdata/flavors" || actualUserAgent != "test-agent/1" {
+		t.Errorf("Unexpected result %v from request to path '%s' with User-Agent '%s'", flavors, actualPath, actualUserAgent)
+	}
+
+	if transport.count != 1 {
+		t.Errorf("Expected the custom HTTP client to make 1 request but it made %d", transport.count)
+	}
+}
+
+func TestNewClientWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithTimeout(20*time.Millisecond))
+
+	if _, err := client.ListAllEffects(); err == nil {
+		t.Error("Expected the request to time out but got no error")
+	}
+}
+
+func TestSetHTTPClientIsReusedAcrossRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+
+	transport := &countingRoundTripper{}
+	previous := client.SetHTTPClient(&http.Client{Transport: transport})
+	if previous == nil {
+		t.Error("Expected the previous HTTP client to be returned but got nil")
+	}
+
+	client.ListAllEffects()
+	client.ListAllFlavors()
+
+	if transport.count != 2 {
+		t.Errorf("Expected the injected HTTP client to make 2 requests but it made %d", transport.count)
+	}
+}
+
+func TestSetBaseURL(t *testing.T) {
+	var actualPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewDefaultClient("test-key")
+	previous := client.SetBaseURL(server.URL + "/mirror/")
+
+	if previous != baseURL {
+		t.Errorf("Expected the previous base URL to be '%s' but got '%s'", baseURL, previous)
+	}
+
+	client.SearchStrainsByRace(RaceSativa)
+
+	if expectedPath := "/mirror/test-key/strains/search/race/sativa"; actualPath != expectedPath {
+		t.Errorf("Expected a request to '%s' but got '%s'", expectedPath, actualPath)
+	}
+}
@@ -0,0 +1,117 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// PairingTaxonomy is a user-supplied table mapping flavors and effect
+// names to suggestions, such as food pairings or activities.
+type PairingTaxonomy struct {
+	Name     string              `json:"name"`
+	ByFlavor map[Flavor][]string `json:"flavors"`
+	ByEffect map[string][]string `json:"effects"`
+}
+
+// LoadPairingTaxonomy reads a PairingTaxonomy from JSON in the form
+// {"name": "food", "flavors": {"Citrus": ["Ceviche"]}, "effects": {"Hungry": ["Pizza"]}}.
+func LoadPairingTaxonomy(r io.Reader) (PairingTaxonomy, error) {
+	taxonomy := PairingTaxonomy{}
+
+	if err := json.NewDecoder(r).Decode(&taxonomy); err != nil {
+		return taxonomy, fmt.Errorf("Problem parsing pairing taxonomy: %w", err)
+	}
+
+	return taxonomy, nil
+}
+
+// Pairing is a single suggestion along with the flavors and effects of
+// the strain that led to it.
+type Pairing struct {
+	Suggestion string
+	Reasons    []string
+}
+
+// PairingSuggester suggests pairings for strains from one or more
+// PairingTaxonomy tables.
+type PairingSuggester struct {
+	client     Client
+	taxonomies []PairingTaxonomy
+}
+
+// NewPairingSuggester creates a new PairingSuggester that looks strains up
+// with client and suggests pairings from taxonomies.
+func NewPairingSuggester(client Client, taxonomies ...PairingTaxonomy) *PairingSuggester {
+	return &PairingSuggester{client: client, taxonomies: taxonomies}
+}
+
+// SuggestPairings returns the pairings for the strain with the ID passed in,
+// keyed by taxonomy name.  Suggestions matching more of the strain's flavors
+// and effects come first.
+func (s *PairingSuggester) SuggestPairings(strainID int) (map[string][]Pairing, error) {
+	flavors, err := s.client.GetStrainFlavorsByStrainID(strainID)
+	if err != nil {
+		return nil, err
+	}
+
+	effects, err := s.client.GetStrainEffectsByStrainID(strainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return SuggestPairingsForStrain(Strain{ID: strainID, Flavors: flavors, Effects: effectNamesByType(effects)}, s.taxonomies...), nil
+}
+
+// SuggestPairingsForStrain returns the pairings for a strain that is already
+// populated, keyed by taxonomy name, without making any calls to the API.
+func SuggestPairingsForStrain(strain Strain, taxonomies ...PairingTaxonomy) map[string][]Pairing {
+	results := make(map[string][]Pairing)
+
+	for _, taxonomy := range taxonomies {
+		reasonsBySuggestion := make(map[string][]string)
+
+		for _, flavor := range strain.Flavors {
+			for _, suggestion := range taxonomy.ByFlavor[flavor] {
+				reasonsBySuggestion[suggestion] = append(reasonsBySuggestion[suggestion], string(flavor))
+			}
+		}
+
+		for _, effectName := range allEffectNames(strain) {
+			for _, suggestion := range taxonomy.ByEffect[effectName] {
+				reasonsBySuggestion[suggestion] = append(reasonsBySuggestion[suggestion], effectName)
+			}
+		}
+
+		pairings := make([]Pairing, 0)
+		for suggestion, reasons := range reasonsBySuggestion {
+			pairings = append(pairings, Pairing{Suggestion: suggestion, Reasons: reasons})
+		}
+
+		sort.Slice(pairings, func(i, j int) bool {
+			if len(pairings[i].Reasons) != len(pairings[j].Reasons) {
+				return len(pairings[i].Reasons) > len(pairings[j].Reasons)
+			}
+			return pairings[i].Suggestion < pairings[j].Suggestion
+		})
+
+		results[taxonomy.Name] = pairings
+	}
+
+	return results
+}
+
+// effectNamesByType converts an EffectsByEffectType into the form used by
+// Strain.Effects.
+func effectNamesByType(effects EffectsByEffectType) map[EffectType][]string {
+	names := make(map[EffectType][]string)
+
+	for effectType, typeEffects := range effects {
+		for _, effect := range typeEffects {
+			names[effectType] = append(names[effectType], effect.Name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,35 @@
+package strainapiclient
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggestPairings(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if strings.Contains(path, "/strains/data/flavors/") {
+			return []byte("[\"Citrus\", \"Pine\"]"), nil
+		}
+		return []byte("{\"positive\": [\"Hungry\", \"Energetic\"], \"negative\": [], \"medical\": []}"), nil
+	})
+
+	taxonomy, err := LoadPairingTaxonomy(strings.NewReader(`{
+		"name": "food",
+		"flavors": {"Citrus": ["Ceviche", "Tacos"], "Pine": ["Trail mix"]},
+		"effects": {"Hungry": ["Tacos"]}
+	}`))
+	if err != nil {
+		t.Errorf("Expected no error loading taxonomy but got: %s", err)
+	}
+
+	pairings, err := NewPairingSuggester(client, taxonomy).SuggestPairings(1)
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	food := pairings["food"]
+	if len(food) != 3 || food[0].Suggestion != "Tacos" || len(food[0].Reasons) != 2 {
+		t.Errorf("Expected Tacos first with 2 reasons out of 3 suggestions; got %v", food)
+	}
+}
@@ -0,0 +1,309 @@
+package strainapiclient
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// soundexCodes maps letters to their Soundex digit; vowels and h, w, y
+// map to 0.
+var soundexCodes = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// Soundex returns the American Soundex code of word (e.g. "R163" for
+// "Robert"), ignoring anything that isn't a letter.  It returns "" if
+// word has no letters.
+func Soundex(word string) string {
+	code := make([]byte, 0, 4)
+	var previous byte
+
+	for _, r := range strings.ToLower(word) {
+		if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+			continue
+		}
+
+		digit, isConsonant := soundexCodes[r]
+
+		if len(code) == 0 {
+			code = append(code, byte(unicode.ToUpper(r)))
+			previous = digit
+			continue
+		}
+
+		if isConsonant && digit != previous {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+
+		// h and w don't separate consonants with the same code; vowels do
+		if r != 'h' && r != 'w' {
+			previous = digit
+		}
+	}
+
+	if len(code) == 0 {
+		return ""
+	}
+
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
+
+// phoneticWords returns the Soundex codes of each word in name.
+func phoneticWords(name string) []string {
+	codes := make([]string, 0)
+
+	for _, word := range strings.Fields(name) {
+		if code := Soundex(word); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
+
+// SearchStrainsByNamePhonetic returns the strains whose names sound like
+// name, for input such as voice-assistant transcriptions where names are
+// often misspelled.  A strain matches when every word of name has the same
+// Soundex code as some word of the strain's name.  Results are sorted by name.
+func (r ListAllStrainsResult) SearchStrainsByNamePhonetic(name string) []Strain {
+	results := make([]Strain, 0)
+
+	queryCodes := phoneticWords(name)
+	if len(queryCodes) == 0 {
+		return results
+	}
+
+	for strainName, strain := range r {
+		nameCodes := make(map[string]bool)
+		for _, code := range phoneticWords(strainName) {
+			nameCodes[code] = true
+		}
+
+		matches := true
+		for _, code := range queryCodes {
+			if !nameCodes[code] {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			results = append(results, strain)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results
+}
+
+// isMetaphoneVowel reports whether b is a vowel for Metaphone.
+func isMetaphoneVowel(b byte) bool {
+	return b == 'A' || b == 'E' || b == 'I' || b == 'O' || b == 'U'
+}
+
+// Metaphone returns the Metaphone key of word (e.g. "KRL" for both
+// "Gorilla" and "Gorrila"), ignoring anything that isn't an ASCII letter.
+// Metaphone follows English pronunciation more closely than Soundex, so it
+// tells apart more names that only share their consonant classes.  It
+// returns "" if word has no letters.
+func Metaphone(word string) string {
+	letters := make([]byte, 0, len(word))
+	for _, r := range strings.ToUpper(word) {
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+
+	if len(letters) == 0 {
+		return ""
+	}
+
+	switch {
+	case len(letters) > 1 && (string(letters[:2]) == "AE" || string(letters[:2]) == "GN" ||
+		string(letters[:2]) == "KN" || string(letters[:2]) == "PN" || string(letters[:2]) == "WR"):
+		letters = letters[1:]
+	case letters[0] == 'X':
+		letters[0] = 'S'
+	case len(letters) > 1 && string(letters[:2]) == "WH":
+		letters = append([]byte{'W'}, letters[2:]...)
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+
+	key := make([]byte, 0, len(letters))
+	for i := 0; i < len(letters); i++ {
+		letter := letters[i]
+
+		if letter != 'C' && i > 0 && at(i-1) == letter {
+			continue
+		}
+
+		switch letter {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				key = append(key, letter)
+			}
+		case 'B':
+			if !(i == len(letters)-1 && at(i-1) == 'M') {
+				key = append(key, 'B')
+			}
+		case 'C':
+			switch {
+			case at(i+1) == 'I' && at(i+2) == 'A', at(i+1) == 'H' && at(i-1) != 'S':
+				key = append(key, 'X')
+			case at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y':
+				if at(i-1) != 'S' {
+					key = append(key, 'S')
+				}
+			default:
+				key = append(key, 'K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && (at(i+2) == 'E' || at(i+2) == 'I' || at(i+2) == 'Y') {
+				key = append(key, 'J')
+			} else {
+				key = append(key, 'T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && !isMetaphoneVowel(at(i+2)):
+			case at(i+1) == 'N' && (i+2 == len(letters) || (at(i+2) == 'E' && at(i+3) == 'D' && i+4 == len(letters))):
+			case (at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y') && at(i-1) != 'G':
+				key = append(key, 'J')
+			default:
+				key = append(key, 'K')
+			}
+		case 'H':
+			previous := at(i - 1)
+			afterVowelOnly := isMetaphoneVowel(previous) && !isMetaphoneVowel(at(i+1))
+			silentAfter := previous == 'C' || previous == 'S' || previous == 'P' || previous == 'T' || previous == 'G'
+			if !afterVowelOnly && !silentAfter {
+				key = append(key, 'H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				key = append(key, 'K')
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				key = append(key, 'F')
+			} else {
+				key = append(key, 'P')
+			}
+		case 'Q':
+			key = append(key, 'K')
+		case 'S':
+			switch {
+			case at(i+1) == 'H', at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				key = append(key, 'X')
+			default:
+				key = append(key, 'S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				key = append(key, 'X')
+			case at(i+1) == 'H':
+				key = append(key, '0')
+			case at(i+1) == 'C' && at(i+2) == 'H':
+			default:
+				key = append(key, 'T')
+			}
+		case 'V':
+			key = append(key, 'F')
+		case 'W', 'Y':
+			if isMetaphoneVowel(at(i + 1)) {
+				key = append(key, letter)
+			}
+		case 'X':
+			key = append(key, 'K', 'S')
+		case 'Z':
+			key = append(key, 'S')
+		default:
+			key = append(key, letter)
+		}
+	}
+
+	return string(key)
+}
+
+// PhoneticMatch is a strain found by RankStrainsByNamePhonetic and how
+// closely its name sounds like the searched name, from 0 to 1.
+type PhoneticMatch struct {
+	Strain Strain  `json:"strain"`
+	Score  float64 `json:"score"`
+}
+
+// RankStrainsByNamePhonetic returns the strains whose names sound somewhat
+// like name, best match first.  Each word of name scores 1 when it has the
+// same Metaphone key as a word of the strain's name and 0.5 when only their
+// Soundex codes match; the total is divided by the number of words in the
+// longer of the two names, so extra words lower the score.  Ties are
+// sorted by name.
+func (r ListAllStrainsResult) RankStrainsByNamePhonetic(name string) []PhoneticMatch {
+	matches := make([]PhoneticMatch, 0)
+
+	queryWords := strings.Fields(name)
+	if len(phoneticWords(name)) == 0 {
+		return matches
+	}
+
+	for strainName, strain := range r {
+		nameWords := strings.Fields(strainName)
+
+		total := 0.0
+		for _, queryWord := range queryWords {
+			best := 0.0
+			for _, nameWord := range nameWords {
+				if metaphone := Metaphone(queryWord); metaphone != "" && metaphone == Metaphone(nameWord) {
+					best = 1
+					break
+				}
+				if soundex := Soundex(queryWord); soundex != "" && soundex == Soundex(nameWord) {
+					best = 0.5
+				}
+			}
+			total += best
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		words := len(queryWords)
+		if len(nameWords) > words {
+			words = len(nameWords)
+		}
+
+		strain.Name = strainName
+		matches = append(matches, PhoneticMatch{Strain: strain, Score: total / float64(words)})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Strain.Name < matches[j].Strain.Name
+	})
+
+	return matches
+}
@@ -0,0 +1,93 @@
+package strainapiclient
+
+import (
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	tests := map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Ashcraft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Gorilla":  "G640",
+		"Gorrila":  "G640",
+		"#4":       "",
+	}
+
+	for word, expected := range tests {
+		if actual := Soundex(word); actual != expected {
+			t.Errorf("Expected Soundex(%q) to be %q but got %q", word, expected, actual)
+		}
+	}
+}
+
+func TestSearchStrainsByNamePhonetic(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Gorilla Glue #4":    {Name: "Gorilla Glue #4"},
+		"Girl Scout Cookies": {Name: "Girl Scout Cookies"},
+		"Blue Dream":         {Name: "Blue Dream"},
+	}
+
+	results := strains.SearchStrainsByNamePhonetic("Gorrila Gloo")
+	if len(results) != 1 || results[0].Name != "Gorilla Glue #4" {
+		t.Errorf("Expected 'Gorrila Gloo' to find Gorilla Glue #4 but got %v", results)
+	}
+
+	if results := strains.SearchStrainsByNamePhonetic("blu dreem"); len(results) != 1 || results[0].Name != "Blue Dream" {
+		t.Errorf("Expected 'blu dreem' to find Blue Dream but got %v", results)
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	tests := map[string]string{
+		"Gorilla":  "KRL",
+		"Gorrila":  "KRL",
+		"Glue":     "KL",
+		"Gloo":     "KL",
+		"Knight":   "NT",
+		"Phantom":  "FNTM",
+		"Thompson": "0MPSN",
+		"Cherry":   "XR",
+		"Diesel":   "TSL",
+		"#4":       "",
+	}
+
+	for word, expected := range tests {
+		if actual := Metaphone(word); actual != expected {
+			t.Errorf("Expected Metaphone(%q) to be %q but got %q", word, expected, actual)
+		}
+	}
+}
+
+func TestRankStrainsByNamePhonetic(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Gorilla Glue":       {ID: 1},
+		"Gorilla Glue #4":    {ID: 2},
+		"Girl Scout Cookies": {ID: 3},
+		"Blue Dream":         {ID: 4},
+	}
+
+	// Girl Scout Cookies only matches through the Soundex code of "Girl"
+	matches := strains.RankStrainsByNamePhonetic("Gorrila Gloo")
+	if len(matches) != 3 {
+		t.Fatalf("Expected 3 matches but got %v", matches)
+	}
+
+	if matches[0].Strain.Name != "Gorilla Glue" || matches[0].Score != 1 {
+		t.Errorf("Expected Gorilla Glue with a score of 1 first but got %v", matches[0])
+	}
+
+	if matches[1].Strain.Name != "Gorilla Glue #4" || matches[1].Score >= 1 {
+		t.Errorf("Expected Gorilla Glue #4 with a lower score second but got %v", matches[1])
+	}
+
+	if matches[2].Strain.Name != "Girl Scout Cookies" || matches[2].Score >= matches[1].Score {
+		t.Errorf("Expected Girl Scout Cookies with the lowest score last but got %v", matches[2])
+	}
+
+	if matches := strains.RankStrainsByNamePhonetic("#4"); len(matches) != 0 {
+		t.Errorf("Expected no matches for a name without letters but got %v", matches)
+	}
+}
@@ -0,0 +1,65 @@
+package strainapiclient
+
+// maxPrefetchQueue is how many strain IDs can wait to be prefetched;
+// further hints are dropped until the queue drains.
+const maxPrefetchQueue int = 100
+
+// PrefetchStrain hints that the strain with id is likely to be opened soon
+// (for example, it is visible in search results), so its description,
+// flavors, and effects are fetched into the cache in the background.  It
+// returns immediately.  Prefetches run one at a time, so they never compete
+// with more than one request's worth of the API's rate limit, and hints are
+// dropped when the queue is full.  Errors are ignored; the next regular
+// call simply fetches the data itself.
+func (c *CachingClient) PrefetchStrain(id int) {
+	c.Prefetch(id)
+}
+
+// Prefetch is PrefetchStrain for several strain IDs, fetched in order.
+func (c *CachingClient) Prefetch(ids ...int) {
+	c.prefetchMutex.Lock()
+	defer c.prefetchMutex.Unlock()
+
+	for _, id := range ids {
+		if len(c.prefetchQueue) >= maxPrefetchQueue {
+			break
+		}
+
+		queued := false
+		for _, queuedID := range c.prefetchQueue {
+			if queuedID == id {
+				queued = true
+				break
+			}
+		}
+
+		if !queued {
+			c.prefetchQueue = append(c.prefetchQueue, id)
+		}
+	}
+
+	if !c.prefetching && len(c.prefetchQueue) > 0 {
+		c.prefetching = true
+		go c.runPrefetches()
+	}
+}
+
+// runPrefetches fetches queued strains until the queue is empty.
+func (c *CachingClient) runPrefetches() {
+	for {
+		c.prefetchMutex.Lock()
+		if len(c.prefetchQueue) == 0 {
+			c.prefetching = false
+			c.prefetchMutex.Unlock()
+			return
+		}
+
+		id := c.prefetchQueue[0]
+		c.prefetchQueue = c.prefetchQueue[1:]
+		c.prefetchMutex.Unlock()
+
+		c.GetStrainDescriptionByStrainID(id)
+		c.GetStrainFlavorsByStrainID(id)
+		c.GetStrainEffectsByStrainID(id)
+	}
+}
@@ -0,0 +1,59 @@
+package strainapiclient
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachingClientPrefetchStrain(t *testing.T) {
+	var mutex sync.Mutex
+	calls := make(map[string]int)
+
+	upstream := NewDefaultClient("test-key")
+	upstream.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		mutex.Lock()
+		calls[path[strings.Index(path, "/strains"):]]++
+		mutex.Unlock()
+
+		switch {
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte("{\"desc\": \"Described\"}"), nil
+		case strings.Contains(path, "/strains/data/effects/"):
+			return []byte("{\"positive\": [\"Relaxed\"]}"), nil
+		}
+		return []byte("[\"Earthy\"]"), nil
+	})
+
+	client := NewCachingClient(upstream, nil)
+	client.Prefetch(1, 2, 1)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mutex.Lock()
+		done := calls["/strains/data/effects/2"] == 1
+		mutex.Unlock()
+
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	client.GetStrainDescriptionByStrainID(1)
+	client.GetStrainFlavorsByStrainID(2)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(calls) != 6 {
+		t.Errorf("Expected 6 distinct prefetch calls but got %v", calls)
+	}
+
+	for path, count := range calls {
+		if count != 1 {
+			t.Errorf("Expected %s to be fetched once and then served from the cache but got %d calls", path, count)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package strainapiclient
+
+import (
+	"strings"
+)
+
+// QualityScore scores how complete a strain's data is, from 0 to 1.  Each
+// of these checks adds 0.25:
+//
+//   - it has a description
+//   - it has at least one flavor
+//   - it has positive, negative, and medical effects
+//   - its name is normalized (no leading, trailing, or repeated spaces)
+//
+// strain must be hydrated (see HydrateStrain and HydrateStrains); the API
+// doesn't list descriptions, so a strain from ListAllStrains always scores
+// as if it had none.  Consumers can use FilterByMinQuality, or
+// StrainQuery.MinQuality, to hide low-quality entries.
+func QualityScore(strain Strain) float64 {
+	score := 0.0
+
+	if strings.TrimSpace(strain.Description) != "" {
+		score += 0.25
+	}
+
+	if len(strain.Flavors) > 0 {
+		score += 0.25
+	}
+
+	hasAllEffectTypes := true
+	for _, effectType := range []EffectType{EffectTypePositive, EffectTypeNegative, EffectTypeMedical} {
+		if len(strain.Effects[effectType]) == 0 {
+			hasAllEffectTypes = false
+		}
+	}
+	if hasAllEffectTypes {
+		score += 0.25
+	}
+
+	if strain.Name != "" && strings.Join(strings.Fields(strain.Name), " ") == strain.Name {
+		score += 0.25
+	}
+
+	return score
+}
+
+// FilterByMinQuality returns a StrainFilter that only includes strains with
+// a QualityScore of at least minScore.  Filter hydrated strains only.
+func FilterByMinQuality(minScore float64) StrainFilter {
+	return func(strain Strain) bool {
+		return QualityScore(strain) >= minScore
+	}
+}
+
+// Filter returns only the strains that pass every filter.
+func (r ListAllStrainsResult) Filter(filters ...StrainFilter) ListAllStrainsResult {
+	filtered := make(ListAllStrainsResult)
+
+	for name, strain := range r {
+		included := true
+		for _, filter := range filters {
+			if !filter(strain) {
+				included = false
+				break
+			}
+		}
+
+		if included {
+			filtered[name] = strain
+		}
+	}
+
+	return filtered
+}
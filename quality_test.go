@@ -0,0 +1,39 @@
+package strainapiclient
+
+import (
+	"testing"
+)
+
+func TestQualityScore(t *testing.T) {
+	complete := Strain{
+		Name:        "Blue Dream",
+		Description: "A sativa-dominant hybrid.",
+		Flavors:     []Flavor{"Berry"},
+		Effects: map[EffectType][]string{
+			EffectTypePositive: {"Happy"},
+			EffectTypeNegative: {"Dry Mouth"},
+			EffectTypeMedical:  {"Stress"},
+		},
+	}
+
+	if score := QualityScore(complete); score != 1 {
+		t.Errorf("Expected a complete strain to score 1 but got %f", score)
+	}
+
+	sparse := Strain{Name: " Blue  Dream", Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}}
+	if score := QualityScore(sparse); score != 0 {
+		t.Errorf("Expected a sparse strain with an unnormalized name to score 0 but got %f", score)
+	}
+}
+
+func TestFilterByMinQuality(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Afpak":      {Name: "Afpak", Description: "An indica-dominant hybrid.", Flavors: []Flavor{"Earthy"}},
+		"Blue Dream": {Name: "Blue Dream"},
+	}
+
+	filtered := strains.Filter(FilterByMinQuality(0.5))
+	if _, found := filtered["Afpak"]; !found || len(filtered) != 1 {
+		t.Errorf("Expected only Afpak to pass the filter but got %v", filtered)
+	}
+}
@@ -0,0 +1,177 @@
+package strainapiclient
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// queryLogEvent is one line of a QueryLog file: a query and its results,
+// or a click on one of the results of an earlier query.
+type queryLogEvent struct {
+	Type      string    `json:"type"`
+	QueryID   string    `json:"queryId"`
+	Time      time.Time `json:"time"`
+	Terms     []string  `json:"terms,omitempty"`
+	ResultIDs []int     `json:"resultIds,omitempty"`
+	StrainID  int       `json:"strainId,omitempty"`
+}
+
+// QueryJudgment is one query exported by QueryLog.Export: its terms, the
+// strain IDs returned in order, and the IDs of the results that were
+// clicked, for offline relevance evaluation of StrainIndex rankings (see
+// CompareRankings).
+type QueryJudgment struct {
+	QueryID   string    `json:"queryId"`
+	Time      time.Time `json:"time"`
+	Terms     []string  `json:"terms"`
+	ResultIDs []int     `json:"resultIds"`
+	Clicked   []int     `json:"clicked"`
+}
+
+// QueryLog is an opt-in, local log of searches and the results users
+// clicked.  Nothing identifying who searched is recorded, and queries are
+// stored as their normalized search terms (as StrainIndex sees them)
+// rather than the raw text.  Events are appended to a file, one JSON
+// object a line.  It is safe for concurrent use.
+//
+//	results := index.Search(query)
+//	queryID, _ := queryLog.Record(query, results)
+//	...
+//	queryLog.RecordClick(queryID, clickedStrainID)
+type QueryLog struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewQueryLog creates a new QueryLog appending to the file at path, which
+// is created by the first event if it doesn't exist.
+func NewQueryLog(path string) *QueryLog {
+	return &QueryLog{path: path}
+}
+
+// Record logs query and the IDs of its results and returns the ID of the
+// query to pass to RecordClick.
+func (l *QueryLog) Record(query string, results []StrainIndexResult) (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("Problem generating query ID: %w", err)
+	}
+
+	event := queryLogEvent{Type: "query", QueryID: hex.EncodeToString(id), Time: time.Now(), Terms: indexTerms(query), ResultIDs: make([]int, 0)}
+	for _, result := range results {
+		event.ResultIDs = append(event.ResultIDs, result.Strain.ID)
+	}
+
+	if err := l.append(event); err != nil {
+		return "", err
+	}
+
+	return event.QueryID, nil
+}
+
+// RecordClick logs that the result with strainID of the query with queryID
+// was clicked.
+func (l *QueryLog) RecordClick(queryID string, strainID int) error {
+	return l.append(queryLogEvent{Type: "click", QueryID: queryID, Time: time.Now(), StrainID: strainID})
+}
+
+// Export writes every logged query to w as JSON Lines of QueryJudgment, in
+// the order they were recorded.  Clicks on queries that aren't in the log
+// are dropped.
+func (l *QueryLog) Export(w io.Writer) error {
+	events, err := l.load()
+	if err != nil {
+		return err
+	}
+
+	judgments := make(map[string]*QueryJudgment)
+	order := make([]string, 0)
+	for _, event := range events {
+		switch event.Type {
+		case "query":
+			judgments[event.QueryID] = &QueryJudgment{QueryID: event.QueryID, Time: event.Time, Terms: event.Terms, ResultIDs: event.ResultIDs, Clicked: make([]int, 0)}
+			order = append(order, event.QueryID)
+		case "click":
+			if judgment, found := judgments[event.QueryID]; found {
+				judgment.Clicked = append(judgment.Clicked, event.StrainID)
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, id := range order {
+		judgment := judgments[id]
+		sort.Ints(judgment.Clicked)
+
+		if err := encoder.Encode(judgment); err != nil {
+			return fmt.Errorf("Problem exporting query %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// append writes event to the end of the log.
+func (l *QueryLog) append(event queryLogEvent) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	line, marshallErr := json.Marshal(event)
+	if marshallErr != nil {
+		return fmt.Errorf("Problem encoding query log event: %w", marshallErr)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Problem opening query log %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("Problem writing query log %s: %w", l.path, err)
+	}
+
+	return nil
+}
+
+// load reads every event in the log.  A log that doesn't exist yet is
+// empty.
+func (l *QueryLog) load() ([]queryLogEvent, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	events := make([]queryLogEvent, 0)
+
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem opening query log %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		event := queryLogEvent{}
+		if marshallErr := json.Unmarshal(scanner.Bytes(), &event); marshallErr != nil {
+			return nil, fmt.Errorf("Problem parsing query log %s: %w", l.path, marshallErr)
+		}
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Problem reading query log %s: %w", l.path, err)
+	}
+
+	return events, nil
+}
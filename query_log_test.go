@@ -0,0 +1,57 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryLogExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-query-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	index := NewStrainIndex(ListAllStrainsResult{
+		"Lemon Haze":  {ID: 1, Flavors: []Flavor{"Citrus"}},
+		"Citrus Kush": {ID: 2},
+	})
+	queryLog := NewQueryLog(filepath.Join(dir, "queries.jsonl"))
+
+	first, err := queryLog.Record("Citrus flavors", index.Search("Citrus flavors"))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+	second, _ := queryLog.Record("kush", index.Search("kush"))
+
+	queryLog.RecordClick(first, 1)
+	queryLog.RecordClick("unknown", 2)
+
+	var out bytes.Buffer
+	if err := queryLog.Export(&out); err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 queries but got %d lines: %s", len(lines), out.String())
+	}
+
+	judgment := QueryJudgment{}
+	json.Unmarshal([]byte(lines[0]), &judgment)
+	if judgment.QueryID != first || !reflect.DeepEqual(judgment.Terms, []string{"citru", "flavor"}) ||
+		!reflect.DeepEqual(judgment.ResultIDs, []int{2, 1}) || !reflect.DeepEqual(judgment.Clicked, []int{1}) {
+		t.Errorf("Expected the first query's terms, results, and click but got %+v", judgment)
+	}
+
+	json.Unmarshal([]byte(lines[1]), &judgment)
+	if judgment.QueryID != second || len(judgment.Clicked) != 0 {
+		t.Errorf("Expected the second query with no clicks but got %+v", judgment)
+	}
+}
@@ -0,0 +1,137 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidQuery is returned (wrapped) by ParseStrainQuery when the query
+// text can't be parsed.
+var ErrInvalidQuery = newCodedError(CodeInvalidQuery, "Invalid query")
+
+// ParseStrainQuery builds a StrainQuery searching through client from text
+// in a small query language, so CLI and web frontends can pass filters
+// typed by users straight through:
+//
+//	race:hybrid effect:relaxed -effect:paranoid flavor:citrus name:og* quality:0.75
+//
+// Terms are separated by spaces and values with spaces are double quoted
+// (flavor:"tree fruit").  The keys are name, race, effect, flavor, and
+// quality, the minimum QualityScore from 0 to 1 (see MinQuality);
+// effects (but nothing else) can be excluded with a leading -.  Words with
+// no key are searched for as a name.  Keys and values are not case
+// sensitive.  An error wrapping ErrInvalidQuery describes the first term
+// that can't be parsed.
+func ParseStrainQuery(client Client, text string) (*StrainQuery, error) {
+	terms, err := queryTerms(text)
+	if err != nil {
+		return nil, err
+	}
+
+	query := NewStrainQuery(client)
+	words := make([]string, 0)
+	named := false
+
+	for _, term := range terms {
+		excluded := strings.HasPrefix(term, "-")
+		term = strings.TrimPrefix(term, "-")
+
+		separator := strings.Index(term, ":")
+		if separator < 0 {
+			if excluded {
+				return nil, fmt.Errorf("Only effects can be excluded, not '-%s': %w", term, ErrInvalidQuery)
+			}
+			words = append(words, term)
+			continue
+		}
+
+		key, value := strings.ToLower(term[:separator]), strings.Trim(term[separator+1:], "\"")
+		if value == "" {
+			return nil, fmt.Errorf("The %s filter has no value: %w", key, ErrInvalidQuery)
+		}
+		if excluded && key != "effect" {
+			return nil, fmt.Errorf("Only effects can be excluded, not '%s': %w", key, ErrInvalidQuery)
+		}
+
+		switch key {
+		case "name":
+			if named {
+				return nil, fmt.Errorf("A query can only have one name: %w", ErrInvalidQuery)
+			}
+			named = true
+			query.Name(value)
+		case "race":
+			race := Race(strings.ToLower(value))
+			switch race {
+			case RaceIndica, RaceSativa, RaceHybrid:
+			default:
+				return nil, fmt.Errorf("Race '%s' is not indica, sativa, or hybrid: %w", value, ErrInvalidQuery)
+			}
+			query.Race(race)
+		case "effect":
+			if excluded {
+				query.Exclude("", titleCase(value))
+			} else {
+				query.WithEffect(titleCase(value))
+			}
+		case "flavor":
+			query.WithFlavor(Flavor(titleCase(value)))
+		case "quality":
+			minScore, err := strconv.ParseFloat(value, 64)
+			if err != nil || minScore < 0 || minScore > 1 {
+				return nil, fmt.Errorf("Quality '%s' is not a number from 0 to 1: %w", value, ErrInvalidQuery)
+			}
+			query.MinQuality(minScore)
+		default:
+			return nil, fmt.Errorf("Unknown filter '%s': %w", key, ErrInvalidQuery)
+		}
+	}
+
+	if len(words) > 0 {
+		if named {
+			return nil, fmt.Errorf("A query can only have one name: %w", ErrInvalidQuery)
+		}
+		query.Name(strings.Join(words, " "))
+	}
+
+	return query, nil
+}
+
+// queryTerms splits text on spaces outside double quotes.
+func queryTerms(text string) ([]string, error) {
+	terms := make([]string, 0)
+	var term strings.Builder
+	quoted := false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("Unterminated quote in query '%s': %w", text, ErrInvalidQuery)
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+
+	return terms, nil
+}
+
+// titleCase capitalizes each word of value, like the API's effect and
+// flavor names.
+func titleCase(value string) string {
+	return strings.Title(strings.ToLower(value))
+}
@@ -0,0 +1,51 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseStrainQuery(t *testing.T) {
+	client, _ := newQueryTestClient()
+
+	query, err := ParseStrainQuery(client, "race:HYBRID effect:relaxed -effect:paranoid flavor:citrus")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	strains, err := query.Run(context.Background())
+	if err != nil || len(strains) != 1 || strains[0].Name != "Blue Dream" {
+		t.Errorf("Expected only Blue Dream but got %v (error: %v)", strains, err)
+	}
+}
+
+func TestParseStrainQueryNames(t *testing.T) {
+	client, _ := newQueryTestClient()
+
+	query, _ := ParseStrainQuery(client, "name:og* flavor:\"tree fruit\"")
+	if query.name != "og*" || !reflect.DeepEqual(query.flavors, []Flavor{"Tree Fruit"}) {
+		t.Errorf("Expected name og* and flavor Tree Fruit but got %q and %v", query.name, query.flavors)
+	}
+
+	query, _ = ParseStrainQuery(client, "quality:0.5 effect:relaxed")
+	if query.minQuality != 0.5 {
+		t.Errorf("Expected a minimum quality of 0.5 but got %g", query.minQuality)
+	}
+
+	query, _ = ParseStrainQuery(client, "blue dream race:sativa")
+	if query.name != "blue dream" || query.race != RaceSativa {
+		t.Errorf("Expected the bare words as the name but got %q", query.name)
+	}
+}
+
+func TestParseStrainQueryErrors(t *testing.T) {
+	client, _ := newQueryTestClient()
+
+	for _, text := range []string{"race:ruderalis", "color:green", "-flavor:citrus", "effect:", "flavor:\"tree fruit", "name:og kush", "quality:high", "quality:2", "-quality:0.5"} {
+		if _, err := ParseStrainQuery(client, text); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Expected ErrInvalidQuery for '%s' but got %v", text, err)
+		}
+	}
+}
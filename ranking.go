@@ -0,0 +1,185 @@
+package strainapiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+)
+
+// RankingConfig is a named set of field weights a StrainIndex ranks
+// matches with, so ranking tweaks can be saved (see RankingStore) and
+// compared offline (see CompareRankings).  A field with no weight isn't
+// searched.
+type RankingConfig struct {
+	Name              string  `json:"name"`
+	NameWeight        float64 `json:"nameWeight"`
+	FlavorWeight      float64 `json:"flavorWeight"`
+	EffectWeight      float64 `json:"effectWeight"`
+	DescriptionWeight float64 `json:"descriptionWeight"`
+}
+
+// DefaultRankingConfig returns the RankingConfig used by NewStrainIndex,
+// so a query matching a strain's name ranks above one matching its
+// description.
+func DefaultRankingConfig() RankingConfig {
+	return RankingConfig{Name: "default", NameWeight: 3, FlavorWeight: 2, EffectWeight: 2, DescriptionWeight: 1}
+}
+
+// RankingStore saves named RankingConfigs to a JSON file.  It is safe for
+// concurrent use within a process.
+type RankingStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewRankingStore creates a new RankingStore saving to the file at path,
+// which is created by the first Save if it doesn't exist.
+func NewRankingStore(path string) *RankingStore {
+	return &RankingStore{path: path}
+}
+
+// Save stores config under its Name, replacing any config with that name.
+func (s *RankingStore) Save(config RankingConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("Ranking configs must have a name: %w", ErrInvalidOptions)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	configs, err := s.load()
+	if err != nil {
+		return err
+	}
+	configs[config.Name] = config
+
+	contents, marshallErr := json.MarshalIndent(configs, "", "  ")
+	if marshallErr != nil {
+		return fmt.Errorf("Problem encoding ranking configs: %w", marshallErr)
+	}
+
+	if err := ioutil.WriteFile(s.path, contents, 0644); err != nil {
+		return fmt.Errorf("Problem writing ranking configs %s: %w", s.path, err)
+	}
+
+	return nil
+}
+
+// Get returns the config saved as name, or an error wrapping ErrNotFound.
+func (s *RankingStore) Get(name string) (RankingConfig, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	configs, err := s.load()
+	if err != nil {
+		return RankingConfig{}, err
+	}
+
+	config, found := configs[name]
+	if !found {
+		return RankingConfig{}, fmt.Errorf("No ranking config is named %s: %w", name, ErrNotFound)
+	}
+
+	return config, nil
+}
+
+// Names returns the names of the saved configs, sorted.
+func (s *RankingStore) Names() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	configs, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// load reads the saved configs; the caller holds mutex.
+func (s *RankingStore) load() (map[string]RankingConfig, error) {
+	configs := make(map[string]RankingConfig)
+
+	contents, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return configs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem reading ranking configs %s: %w", s.path, err)
+	}
+
+	if marshallErr := json.Unmarshal(contents, &configs); marshallErr != nil {
+		return nil, fmt.Errorf("Problem parsing ranking configs %s: %w", s.path, marshallErr)
+	}
+
+	return configs, nil
+}
+
+// RankingDifference is where one strain ranked for a query under each of
+// two RankingConfigs.  Ranks start at 1; a rank of 0 means the strain
+// wasn't matched under that config.
+type RankingDifference struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	RankA  int     `json:"rankA"`
+	RankB  int     `json:"rankB"`
+	ScoreA float64 `json:"scoreA"`
+	ScoreB float64 `json:"scoreB"`
+}
+
+// RankingComparison is the result of CompareRankings: every strain either
+// config matched, in A's order followed by the strains only B matched in
+// B's order, and how many of them ranked differently.
+type RankingComparison struct {
+	Query   string              `json:"query"`
+	A       string              `json:"a"`
+	B       string              `json:"b"`
+	Results []RankingDifference `json:"results"`
+	Moved   int                 `json:"moved"`
+}
+
+// CompareRankings searches strains for query with a StrainIndex ranked by
+// each of configA and configB and shows how the orderings differ, so
+// ranking tweaks can be evaluated offline before shipping them.
+func CompareRankings(strains ListAllStrainsResult, configA RankingConfig, configB RankingConfig, query string) RankingComparison {
+	resultsA := NewStrainIndexWithRanking(strains, configA).Search(query)
+	resultsB := NewStrainIndexWithRanking(strains, configB).Search(query)
+
+	comparison := RankingComparison{Query: query, A: configA.Name, B: configB.Name, Results: make([]RankingDifference, 0)}
+	byID := make(map[int]int)
+
+	for rank, result := range resultsA {
+		byID[result.Strain.ID] = len(comparison.Results)
+		comparison.Results = append(comparison.Results, RankingDifference{
+			ID: result.Strain.ID, Name: result.Strain.Name, RankA: rank + 1, ScoreA: result.Score,
+		})
+	}
+
+	for rank, result := range resultsB {
+		index, found := byID[result.Strain.ID]
+		if !found {
+			index = len(comparison.Results)
+			comparison.Results = append(comparison.Results, RankingDifference{ID: result.Strain.ID, Name: result.Strain.Name})
+		}
+
+		comparison.Results[index].RankB = rank + 1
+		comparison.Results[index].ScoreB = result.Score
+	}
+
+	for _, difference := range comparison.Results {
+		if difference.RankA != difference.RankB {
+			comparison.Moved++
+		}
+	}
+
+	return comparison
+}
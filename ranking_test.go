@@ -0,0 +1,65 @@
+package strainapiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRankingStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-ranking")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewRankingStore(filepath.Join(dir, "rankings.json"))
+	if _, err := store.Get("default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound before saving but got %v", err)
+	}
+
+	flavorFirst := RankingConfig{Name: "flavor-first", NameWeight: 1, FlavorWeight: 5, EffectWeight: 2}
+	store.Save(DefaultRankingConfig())
+	store.Save(flavorFirst)
+
+	if config, err := store.Get("flavor-first"); err != nil || !reflect.DeepEqual(config, flavorFirst) {
+		t.Errorf("Expected %v but got %v (error: %v)", flavorFirst, config, err)
+	}
+
+	if names, _ := store.Names(); !reflect.DeepEqual(names, []string{"default", "flavor-first"}) {
+		t.Errorf("Expected both configs to be saved but got %v", names)
+	}
+
+	if err := store.Save(RankingConfig{}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for an unnamed config but got %v", err)
+	}
+}
+
+func TestCompareRankings(t *testing.T) {
+	strains := ListAllStrainsResult{
+		"Lemon Haze":  {ID: 1, Flavors: []Flavor{"Citrus"}},
+		"Citrus Kush": {ID: 2, Flavors: []Flavor{"Earthy"}},
+		"Sour Diesel": {ID: 3, Description: "A citrus aroma"},
+	}
+
+	nameFirst := RankingConfig{Name: "name-first", NameWeight: 5, FlavorWeight: 1}
+	flavorFirst := RankingConfig{Name: "flavor-first", NameWeight: 1, FlavorWeight: 5, DescriptionWeight: 1}
+
+	comparison := CompareRankings(strains, nameFirst, flavorFirst, "citrus")
+
+	expected := []RankingDifference{
+		{ID: 2, Name: "Citrus Kush", RankA: 1, RankB: 2},
+		{ID: 1, Name: "Lemon Haze", RankA: 2, RankB: 1},
+		{ID: 3, Name: "Sour Diesel", RankA: 0, RankB: 3},
+	}
+	for i := range comparison.Results {
+		comparison.Results[i].ScoreA, comparison.Results[i].ScoreB = 0, 0
+	}
+
+	if !reflect.DeepEqual(comparison.Results, expected) || comparison.Moved != 3 {
+		t.Errorf("Expected %v with 3 moved but got %v with %d moved", expected, comparison.Results, comparison.Moved)
+	}
+}
@@ -0,0 +1,46 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// SearchStrainsByNameRegex returns the strains whose names match the
+// regular expression pattern (RE2 syntax, see package regexp), sorted by
+// name.  Prefix pattern with (?i) to ignore case.
+func (r ListAllStrainsResult) SearchStrainsByNameRegex(pattern string) ([]Strain, error) {
+	return r.searchRegex(pattern, func(strain Strain) string { return strain.Name })
+}
+
+// SearchStrainsByDescriptionRegex returns the strains whose descriptions
+// match the regular expression pattern, sorted by name.  Descriptions are
+// only set on hydrated datasets (see ExportJSONLines and TakeSnapshot, which
+// hydrates every strain), so strains without one never match.
+func (r ListAllStrainsResult) SearchStrainsByDescriptionRegex(pattern string) ([]Strain, error) {
+	return r.searchRegex(pattern, func(strain Strain) string { return strain.Description })
+}
+
+// searchRegex returns the strains for which field matches pattern.
+func (r ListAllStrainsResult) searchRegex(pattern string, field func(strain Strain) string) ([]Strain, error) {
+	results := make([]Strain, 0)
+
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return results, fmt.Errorf("Problem compiling search pattern '%s': %w", pattern, err)
+	}
+
+	for name, strain := range r {
+		if strain.Name == "" {
+			strain.Name = name
+		}
+
+		if field(strain) != "" && expression.MatchString(field(strain)) {
+			results = append(results, strain)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results, nil
+}
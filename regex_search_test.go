@@ -0,0 +1,45 @@
+package strainapiclient
+
+import "testing"
+
+func newRegexTestStrains() ListAllStrainsResult {
+	return ListAllStrainsResult{
+		"Blue Dream":  {ID: 1, Description: "A sativa-dominant hybrid."},
+		"Blueberry":   {ID: 2, Description: "An indica with berry notes."},
+		"Sour Diesel": {ID: 3},
+		"OG Kush #2":  {ID: 4, Description: "Duplicate of OG Kush."},
+	}
+}
+
+func TestSearchStrainsByNameRegex(t *testing.T) {
+	strains := newRegexTestStrains()
+
+	results, err := strains.SearchStrainsByNameRegex("(?i)^blue")
+	if err != nil || len(results) != 2 || results[0].Name != "Blue Dream" || results[1].Name != "Blueberry" {
+		t.Errorf("Expected Blue Dream and Blueberry but got %v and error %v", results, err)
+	}
+
+	if results, _ := strains.SearchStrainsByNameRegex(`#\d+$`); len(results) != 1 || results[0].ID != 4 {
+		t.Errorf("Expected OG Kush #2 but got %v", results)
+	}
+}
+
+func TestSearchStrainsByDescriptionRegex(t *testing.T) {
+	strains := newRegexTestStrains()
+
+	results, err := strains.SearchStrainsByDescriptionRegex(`\b(indica|sativa)\b`)
+	if err != nil || len(results) != 2 {
+		t.Errorf("Expected 2 results but got %v and error %v", results, err)
+	}
+
+	// Strains without a description never match, even an empty pattern
+	if results, _ := strains.SearchStrainsByDescriptionRegex(""); len(results) != 3 {
+		t.Errorf("Expected the 3 strains with descriptions but got %v", results)
+	}
+}
+
+func TestSearchStrainsRegexInvalidPattern(t *testing.T) {
+	if _, err := newRegexTestStrains().SearchStrainsByNameRegex("(unclosed"); err == nil {
+		t.Error("Expected an error for an invalid pattern but got none")
+	}
+}
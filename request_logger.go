@@ -0,0 +1,105 @@
+package strainapiclient
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// requestLoggerSecretPlaceholder replaces secrets (such as the API Key) in
+// logged paths and errors.
+const requestLoggerSecretPlaceholder string = "REDACTED"
+
+// RequestLogSampling controls which requests a RequestLogger logs.  A
+// request is logged if any rule selects it.
+type RequestLogSampling struct {
+	// Rate is the chance, between 0 and 1, that any request is logged.
+	Rate float64
+	// AlwaysLogErrors logs every request that fails.
+	AlwaysLogErrors bool
+	// SlowThreshold logs every request taking at least this long (0 disables it).
+	SlowThreshold time.Duration
+	// Seed seeds the random source so sampling is reproducible.
+	Seed int64
+}
+
+// RequestLogger wraps request handlers to log requests, sampled so
+// production logs aren't flooded: a fraction of all requests, plus every
+// failed or slow request if configured.  Secrets, such as the API Key,
+// are redacted from logged paths and errors.
+type RequestLogger struct {
+	logger   *log.Logger
+	sampling RequestLogSampling
+	secrets  []string
+
+	mutex sync.Mutex
+	rand  *rand.Rand
+}
+
+// NewRequestLogger creates a new RequestLogger that writes to logger (one
+// with the standard logger's output, prefix, and flags if nil) and
+// redacts secrets.
+func NewRequestLogger(logger *log.Logger, sampling RequestLogSampling, secrets ...string) *RequestLogger {
+	if logger == nil {
+		logger = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+
+	return &RequestLogger{
+		logger:   logger,
+		sampling: sampling,
+		secrets:  secrets,
+		rand:     rand.New(rand.NewSource(sampling.Seed)),
+	}
+}
+
+// Wrap returns a HandleResourceRequestFunc that logs the requests made
+// through next.
+func (l *RequestLogger) Wrap(next HandleResourceRequestFunc) HandleResourceRequestFunc {
+	wrapped := l.WrapContext(func(ctx context.Context, resourcePath string) ([]byte, error) {
+		return next(resourcePath)
+	})
+
+	return func(resourcePath string) ([]byte, error) {
+		return wrapped(context.Background(), resourcePath)
+	}
+}
+
+// WrapContext returns a HandleResourceRequestContextFunc that logs the
+// requests made through next.
+func (l *RequestLogger) WrapContext(next HandleResourceRequestContextFunc) HandleResourceRequestContextFunc {
+	return func(ctx context.Context, resourcePath string) ([]byte, error) {
+		start := time.Now()
+		body, err := next(ctx, resourcePath)
+		elapsed := time.Since(start)
+
+		if l.sampled(err, elapsed) {
+			path := redactSecrets(resourcePath, l.secrets, requestLoggerSecretPlaceholder)
+			if err != nil {
+				message := redactSecrets(err.Error(), l.secrets, requestLoggerSecretPlaceholder)
+				l.logger.Printf("GET %s failed after %s: %s", path, elapsed, message)
+			} else {
+				l.logger.Printf("GET %s returned %d bytes in %s", path, len(body), elapsed)
+			}
+		}
+
+		return body, err
+	}
+}
+
+// sampled reports whether a request should be logged.
+func (l *RequestLogger) sampled(err error, elapsed time.Duration) bool {
+	if err != nil && l.sampling.AlwaysLogErrors {
+		return true
+	}
+
+	if l.sampling.SlowThreshold > 0 && elapsed >= l.sampling.SlowThreshold {
+		return true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.rand.Float64() < l.sampling.Rate
+}
@@ -0,0 +1,59 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestLoggerSampling(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewRequestLogger(log.New(&buffer, "", 0), RequestLogSampling{AlwaysLogErrors: true, SlowThreshold: 20 * time.Millisecond}, "secret-key")
+
+	failing := true
+	client := NewClient("secret-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(logger.Wrap(func(path string) ([]byte, error) {
+		if failing {
+			return make([]byte, 0), &APIError{StatusCode: 500, Body: "boom"}
+		}
+		if strings.Contains(path, "flavors") {
+			time.Sleep(25 * time.Millisecond)
+		}
+		return []byte("[]"), nil
+	}))
+
+	client.ListAllEffects()
+	failing = false
+	client.ListAllEffects()
+	client.ListAllFlavors()
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Errorf("Expected the failed and slow requests to be logged but got:\n%s", buffer.String())
+		return
+	}
+
+	if !strings.Contains(lines[0], "failed") || !strings.Contains(lines[1], "searchdata/flavors") {
+		t.Errorf("Expected a failure and then the slow flavors request but got:\n%s", buffer.String())
+	}
+
+	if strings.Contains(buffer.String(), "secret-key") || !strings.Contains(lines[0], "/"+requestLoggerSecretPlaceholder+"/") {
+		t.Errorf("Expected the API Key to be redacted but got:\n%s", buffer.String())
+	}
+}
+
+func TestRequestLoggerRate(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewRequestLogger(log.New(&buffer, "", 0), RequestLogSampling{Rate: 1})
+
+	handler := logger.Wrap(alwaysEffectsHandler)
+	for i := 0; i < 3; i++ {
+		handler("/test-key/searchdata/effects")
+	}
+
+	if lines := strings.Count(buffer.String(), "\n"); lines != 3 {
+		t.Errorf("Expected every request to be logged at a rate of 1 but got %d lines", lines)
+	}
+}
@@ -0,0 +1,115 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how the default request handler retries requests
+// that fail with a 5xx status, a 429 status, a timeout, or a refused or
+// reset connection.  Other network errors, such as an unknown host or a
+// bad certificate, aren't retried.  Only GET requests are made, so every
+// request is safe to retry.
+//
+// When a response has a Retry-After header, the client waits that long
+// instead of the backoff delay.  If Retry-After is longer than MaxDelay,
+// the request is not retried and ErrRateLimited is returned right away.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first;
+	// 1 or less disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles for each
+	// retry after that.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomized,
+	// so many clients don't retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the RetryPolicy used when none is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+}
+
+// NoRetryPolicy disables retries.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// do calls attempt until it succeeds, fails with an error that isn't
+// worth retrying, the attempts run out, or the context is done.
+func (p RetryPolicy) do(ctx context.Context, attempt func() ([]byte, error)) ([]byte, error) {
+	for attemptNumber := 1; ; attemptNumber++ {
+		body, err := attempt()
+		if err == nil || attemptNumber >= p.MaxAttempts || !isRetryable(ctx, err) {
+			return body, err
+		}
+
+		delay := p.delay(attemptNumber)
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+				return body, err
+			}
+			delay = apiErr.RetryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return body, err
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long to wait after the attempt numbered attemptNumber fails.
+func (p RetryPolicy) delay(attemptNumber int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attemptNumber && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := float64(delay) * p.Jitter
+		delay = time.Duration(float64(delay) - jitter + rand.Float64()*2*jitter)
+	}
+
+	return delay
+}
+
+// isRetryable reports whether err is a transient failure worth retrying.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, ErrServerError) || errors.Is(err, ErrRateLimited) {
+		return true
+	}
+
+	// ctx isn't done, so a deadline was the attempt's own timeout
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,175 @@
+package strainapiclient
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyRetriesServerErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("[\"Earthy\"]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	flavors, err := client.ListAllFlavors()
+	if err != nil || len(flavors) != 1 || requests != 3 {
+		t.Errorf("Expected success on the third attempt; got %v after %d requests (error: %v)", flavors, requests, err)
+	}
+}
+
+func TestRetryPolicyDoesNotRetryClientErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	if _, err := client.ListAllFlavors(); !errors.Is(err, ErrNotFound) || requests != 1 {
+		t.Errorf("Expected one request failing with ErrNotFound; got %d requests (error: %v)", requests, err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	urlError := func(err error) error {
+		return fmt.Errorf("There was a problem connecting to the api: %w", &url.Error{Op: "Get", URL: "https://example.com", Err: err})
+	}
+	dialError := func(errno syscall.Errno) error {
+		return urlError(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errno)})
+	}
+
+	for _, test := range []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"server error", &APIError{StatusCode: http.StatusBadGateway}, true},
+		{"client error", &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"connection refused", dialError(syscall.ECONNREFUSED), true},
+		{"connection reset", dialError(syscall.ECONNRESET), true},
+		{"timeout", urlError(&net.DNSError{Err: "i/o timeout", IsTimeout: true}), true},
+		{"attempt timeout", fmt.Errorf("Problem: %w", context.DeadlineExceeded), true},
+		{"unknown host", urlError(&net.DNSError{Err: "no such host", IsNotFound: true}), false},
+		{"bad certificate", urlError(x509.UnknownAuthorityError{}), false},
+		{"bad scheme", urlError(errors.New("unsupported protocol scheme \"ftp\"")), false},
+	} {
+		if actual := isRetryable(context.Background(), test.err); actual != test.expected {
+			t.Errorf("Expected isRetryable to be %v for a %s but got %v", test.expected, test.name, actual)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if isRetryable(ctx, dialError(syscall.ECONNREFUSED)) {
+		t.Error("Expected nothing to be retried once the context is done")
+	}
+}
+
+func TestRetryPolicyDoesNotRetryUnknownHosts(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	policy.do(context.Background(), func() ([]byte, error) {
+		attempts++
+		return nil, &url.Error{Op: "Get", URL: "https://example.invalid", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}
+	})
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt for an unknown host but got %d", attempts)
+	}
+}
+
+func TestNoRetryPolicy(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy))
+
+	if _, err := client.ListAllFlavors(); !errors.Is(err, ErrServerError) || requests != 1 {
+		t.Errorf("Expected one request failing with ErrServerError; got %d requests (error: %v)", requests, err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for index, expectedDelay := range expected {
+		if actual := policy.delay(index + 1); actual != expectedDelay {
+			t.Errorf("Expected delay %s after attempt %d but got %s", expectedDelay, index+1, actual)
+		}
+	}
+}
+
+func TestRetryPolicyHonorsRetryAfter(t *testing.T) {
+	requests := 0
+	var firstRequest, secondRequest time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			firstRequest = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondRequest = time.Now()
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}))
+
+	if _, err := client.ListAllFlavors(); err != nil || requests != 2 {
+		t.Errorf("Expected success on the second attempt; got %d requests (error: %v)", requests, err)
+	}
+
+	if waited := secondRequest.Sub(firstRequest); waited < time.Second {
+		t.Errorf("Expected to wait at least 1s for Retry-After but waited %s", waited)
+	}
+}
+
+func TestRetryPolicySurfacesRateLimited(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}))
+
+	var apiErr *APIError
+	_, err := client.ListAllFlavors()
+	if !errors.Is(err, ErrRateLimited) || !errors.As(err, &apiErr) || apiErr.RetryAfter != 2*time.Minute || requests != 1 {
+		t.Errorf("Expected ErrRateLimited after 1 request with a 2m RetryAfter; got %d requests (error: %v)", requests, err)
+	}
+}
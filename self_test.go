@@ -0,0 +1,113 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+)
+
+// The names of the checks run by DefaultClient.SelfTest
+const (
+	SelfTestCheckAPIKey string = "api-key"
+	SelfTestCheckSearch        = "search"
+	SelfTestCheckDecode        = "decode"
+	SelfTestCheckCache         = "cache"
+)
+
+// SelfTestCheck is the outcome of one check run by SelfTest.  Error is set
+// when the check failed; Skipped checks neither passed nor failed.
+type SelfTestCheck struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// SelfTestReport is the result of SelfTest.  Passed is true when no check
+// failed.
+type SelfTestReport struct {
+	Passed bool            `json:"passed"`
+	Checks []SelfTestCheck `json:"checks"`
+}
+
+// SelfTest runs a small battery of checks, for service readiness probes:
+// the API accepts the API Key, a cheap search (for sativa strains)
+// succeeds, its response decodes into sensible values, and the cache configured with
+// WithConditionalRequests (if any) can be written and read back.  The
+// checks that call the API are skipped once one fails.
+func (c *DefaultClient) SelfTest(ctx context.Context) SelfTestReport {
+	report := SelfTestReport{Passed: true, Checks: make([]SelfTestCheck, 0)}
+	apiFailed := false
+
+	run := func(name string, skip bool, check func() error) {
+		result := SelfTestCheck{Name: name, Skipped: skip}
+
+		if !skip {
+			start := time.Now()
+			err := check()
+			result.Duration = time.Since(start)
+
+			if err != nil {
+				result.Error = err.Error()
+				report.Passed = false
+			} else {
+				result.Passed = true
+			}
+		}
+
+		report.Checks = append(report.Checks, result)
+	}
+
+	run(SelfTestCheckAPIKey, false, func() error {
+		if !c.CanConnectContext(ctx) {
+			apiFailed = true
+			return fmt.Errorf("The API did not accept the API Key: %w", ErrUnauthorized)
+		}
+		return nil
+	})
+
+	var results SearchStrainsByRaceResults
+	run(SelfTestCheckSearch, apiFailed, func() error {
+		var err error
+		if results, err = c.SearchStrainsByRaceContext(ctx, RaceSativa); err != nil {
+			apiFailed = true
+			return fmt.Errorf("Problem searching for sativa strains: %w", err)
+		}
+		return nil
+	})
+
+	run(SelfTestCheckDecode, apiFailed, func() error {
+		if len(results) == 0 {
+			return fmt.Errorf("Expected strains in the search results but got none")
+		}
+		for _, result := range results {
+			if result.Name == "" || result.ID <= 0 || result.Race != RaceSativa {
+				return fmt.Errorf("Decoded an unexpected search result %+v", result)
+			}
+		}
+		return nil
+	})
+
+	run(SelfTestCheckCache, c.conditionalCache == nil, func() error {
+		key := fmt.Sprintf("self-test/%d", time.Now().UnixNano())
+		value := []byte("self-test")
+		defer c.conditionalCache.Delete(key)
+
+		if err := c.conditionalCache.Set(key, value, time.Minute); err != nil {
+			return fmt.Errorf("Problem writing to the cache: %w", err)
+		}
+
+		cached, found, err := c.conditionalCache.Get(key)
+		if err != nil {
+			return fmt.Errorf("Problem reading from the cache: %w", err)
+		}
+		if !found || !bytes.Equal(cached, value) {
+			return fmt.Errorf("The cache did not return the value written to it")
+		}
+		return nil
+	})
+
+	return report
+}
@@ -0,0 +1,78 @@
+package strainapiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newSelfTestServer(searchJSON string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !strings.HasPrefix(r.URL.Path, "/test-key"):
+			w.WriteHeader(http.StatusUnauthorized)
+		case strings.HasSuffix(r.URL.Path, "/strains/search/race/sativa"):
+			w.Write([]byte(searchJSON))
+		default:
+			w.Write([]byte("Seems legit to me man..."))
+		}
+	}))
+}
+
+func TestSelfTestPasses(t *testing.T) {
+	server := newSelfTestServer("[{\"id\": 1, \"name\": \"Durban Poison\", \"race\": \"sativa\"}]")
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL), WithConditionalRequests(nil))
+	report := client.SelfTest(context.Background())
+
+	if !report.Passed || len(report.Checks) != 4 {
+		t.Errorf("Expected 4 passing checks but got %+v", report)
+	}
+
+	for _, check := range report.Checks {
+		if !check.Passed {
+			t.Errorf("Expected check %s to pass but got %+v", check.Name, check)
+		}
+	}
+}
+
+func TestSelfTestBadKey(t *testing.T) {
+	server := newSelfTestServer("[{\"id\": 1, \"name\": \"Durban Poison\", \"race\": \"sativa\"}]")
+	defer server.Close()
+
+	client := NewClient("wrong-key", WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy))
+	report := client.SelfTest(context.Background())
+
+	if report.Passed {
+		t.Error("Expected the self-test to fail with a rejected key")
+	}
+
+	expected := map[string]string{SelfTestCheckAPIKey: "failed", SelfTestCheckSearch: "skipped", SelfTestCheckDecode: "skipped", SelfTestCheckCache: "skipped"}
+	for _, check := range report.Checks {
+		outcome := "failed"
+		if check.Skipped {
+			outcome = "skipped"
+		} else if check.Passed {
+			outcome = "passed"
+		}
+
+		if outcome != expected[check.Name] {
+			t.Errorf("Expected check %s to be %s but it %s", check.Name, expected[check.Name], outcome)
+		}
+	}
+}
+
+func TestSelfTestDecodeFailure(t *testing.T) {
+	server := newSelfTestServer("[]")
+	defer server.Close()
+
+	client := NewClient("test-key", WithBaseURL(server.URL))
+	report := client.SelfTest(context.Background())
+
+	if report.Passed || report.Checks[2].Name != SelfTestCheckDecode || report.Checks[2].Error == "" {
+		t.Errorf("Expected the decode check to fail but got %+v", report)
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+// ProblemContentType is the media type of Problem responses (RFC 7807).
+const ProblemContentType string = "application/problem+json"
+
+// CorrelationIDHeader carries the ID that ties a request to its response,
+// its Problem, and any logs about it.
+const CorrelationIDHeader string = "X-Correlation-ID"
+
+// Stable codes for problems with the request itself, rather than with
+// calling the Strain API.
+const (
+	CodeBadRequest       strainapiclient.Code = "strainapi/bad_request"
+	CodeUnknownRoute     strainapiclient.Code = "strainapi/unknown_route"
+	CodeMethodNotAllowed strainapiclient.Code = "strainapi/method_not_allowed"
+)
+
+// Problem is an RFC 7807 problem details body, extended with the stable
+// strainapiclient error code.
+type Problem struct {
+	Type   string               `json:"type"`
+	Title  string               `json:"title"`
+	Status int                  `json:"status"`
+	Detail string               `json:"detail,omitempty"`
+	Code   strainapiclient.Code `json:"code,omitempty"`
+	// CorrelationID matches the CorrelationIDHeader of the response.
+	CorrelationID string `json:"correlationId,omitempty"`
+}
+
+// problemDetails are safe descriptions of each error code.  Error messages
+// themselves aren't used since they can contain upstream URLs and the API
+// Key.
+var problemDetails = map[strainapiclient.Code]string{
+	strainapiclient.CodeNotFound:     "The requested strain data was not found.",
+	strainapiclient.CodeUnauthorized: "The Strain API rejected the configured API Key.",
+	strainapiclient.CodeRateLimited:  "The Strain API is rate limiting requests.",
+	strainapiclient.CodeServerError:  "The Strain API failed to handle the request.",
+	strainapiclient.CodeAPIError:     "The Strain API returned an unexpected response.",
+	strainapiclient.CodeCircuitOpen:  "Calls to the Strain API are paused after repeated failures.",
+	strainapiclient.CodeTimeout:      "The Strain API did not respond in time.",
+	strainapiclient.CodeCanceled:     "The request was canceled.",
+}
+
+// StatusForError returns the HTTP status a server should respond with for
+// an error returned by a strainapiclient.Client.  Upstream failures that
+// aren't the caller's fault, including a rejected API Key, map to 502 Bad
+// Gateway; unknown errors map to 502 as well since they come from calling
+// the Strain API.  A nil error maps to 200 OK.
+func StatusForError(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, strainapiclient.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, strainapiclient.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, strainapiclient.ErrCircuitOpen), errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	case strainapiclient.ErrorCode(err) == strainapiclient.CodeTimeout:
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusBadGateway
+}
+
+// ProblemForError returns the Problem describing an error returned by a
+// strainapiclient.Client, with the status from StatusForError.
+func ProblemForError(err error) Problem {
+	status := StatusForError(err)
+	code := strainapiclient.ErrorCode(err)
+
+	detail, found := problemDetails[code]
+	if !found {
+		detail = "There was a problem calling the Strain API."
+	}
+
+	return Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code}
+}
+
+// WriteProblem writes problem as an application/problem+json response.  If
+// the problem has no CorrelationID, the response's CorrelationIDHeader is
+// used.
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	if problem.CorrelationID == "" {
+		problem.CorrelationID = w.Header().Get(CorrelationIDHeader)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// WriteError writes the Problem for err, adding a Retry-After header when
+// the Strain API sent one with a rate limit.
+func WriteError(w http.ResponseWriter, err error) {
+	var apiErr *strainapiclient.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(apiErr.RetryAfter.Seconds()+0.5)))
+	}
+
+	WriteProblem(w, ProblemForError(err))
+}
+
+// writeRequestProblem writes a Problem about the request itself.
+func writeRequestProblem(w http.ResponseWriter, status int, code strainapiclient.Code, detail string) {
+	WriteProblem(w, Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code})
+}
+
+// correlationID returns the request's correlation ID, or a new random one
+// if it doesn't have one.
+func correlationID(r *http.Request) string {
+	if id := r.Header.Get(CorrelationIDHeader); id != "" {
+		return id
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
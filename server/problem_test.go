@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{nil, http.StatusOK},
+		{fmt.Errorf("Missing: %w", strainapiclient.ErrNotFound), http.StatusNotFound},
+		{&strainapiclient.APIError{StatusCode: http.StatusTooManyRequests}, http.StatusTooManyRequests},
+		{&strainapiclient.APIError{StatusCode: http.StatusUnauthorized}, http.StatusBadGateway},
+		{&strainapiclient.APIError{StatusCode: http.StatusInternalServerError}, http.StatusBadGateway},
+		{strainapiclient.ErrCircuitOpen, http.StatusServiceUnavailable},
+		{fmt.Errorf("Problem: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{errors.New("Something else"), http.StatusBadGateway},
+	}
+
+	for _, test := range tests {
+		if status := StatusForError(test.err); status != test.expected {
+			t.Errorf("Expected status %d for %v but got %d", test.expected, test.err, status)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, &strainapiclient.APIError{StatusCode: http.StatusTooManyRequests, Body: "secret", RetryAfter: 2 * time.Second})
+
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "2" {
+		t.Errorf("Expected a 429 with Retry-After 2 but got %d with '%s'", recorder.Code, recorder.Header().Get("Retry-After"))
+	}
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Errorf("Expected Content-Type %s but got %s", ProblemContentType, contentType)
+	}
+
+	problem := Problem{}
+	if err := json.NewDecoder(recorder.Body).Decode(&problem); err != nil {
+		t.Errorf("Problem parsing problem body: %s", err)
+	}
+
+	if problem.Status != http.StatusTooManyRequests || problem.Code != strainapiclient.CodeRateLimited || problem.Title != "Too Many Requests" {
+		t.Errorf("Expected a rate limited problem but got %+v", problem)
+	}
+}
@@ -0,0 +1,134 @@
+// Package server exposes a strainapiclient.Client through a small HTTP API,
+// so teams can deploy one proxy that holds the Strain API Key instead of
+// distributing it to every service.
+//
+// Routes (all GET, all JSON; errors are application/problem+json):
+//
+//	/strains                              every strain, keyed by name
+//	/strains/{id}                         a strain's description, flavors, and effects
+//	/search?name=|race=|effect=|flavor=   strains matching one search criterion
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+// StrainDetails is the response for /strains/{id}.
+type StrainDetails struct {
+	ID          int                                 `json:"id"`
+	Description string                              `json:"desc"`
+	Flavors     []strainapiclient.Flavor            `json:"flavors"`
+	Effects     strainapiclient.EffectsByEffectType `json:"effects"`
+}
+
+// Server is an http.Handler serving the proxy routes.  Results are cached
+// with a strainapiclient.CachingClient.  Client errors are written with
+// WriteError, which never includes upstream URLs or messages, so the API
+// Key can't leak to callers.
+type Server struct {
+	client strainapiclient.Client
+}
+
+// New creates a new Server that answers requests with client, caching its
+// results for ttls (strainapiclient.DefaultCacheTTLs() if nil).
+func New(client strainapiclient.Client, ttls strainapiclient.CacheTTLs) *Server {
+	return &Server{client: strainapiclient.NewCachingClient(client, ttls)}
+}
+
+// ServeHTTP implements http.Handler.  Every response carries a
+// correlation ID in the CorrelationIDHeader: the caller's, if the request
+// had one, or a new random ID.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(CorrelationIDHeader, correlationID(r))
+
+	if r.Method != http.MethodGet {
+		writeRequestProblem(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Only GET requests are supported.")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "strains":
+		s.serveStrains(w)
+	case len(segments) == 2 && segments[0] == "strains":
+		s.serveStrain(w, segments[1])
+	case len(segments) == 1 && segments[0] == "search":
+		s.serveSearch(w, r)
+	default:
+		writeRequestProblem(w, http.StatusNotFound, CodeUnknownRoute, "There is no route for the requested path.")
+	}
+}
+
+func (s *Server) serveStrains(w http.ResponseWriter) {
+	strains, err := s.client.ListAllStrains()
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, strains)
+}
+
+func (s *Server) serveStrain(w http.ResponseWriter, idSegment string) {
+	id, err := strconv.Atoi(idSegment)
+	if err != nil {
+		writeRequestProblem(w, http.StatusBadRequest, CodeBadRequest, "The strain ID must be a number.")
+		return
+	}
+
+	details := StrainDetails{ID: id}
+
+	if details.Description, err = s.client.GetStrainDescriptionByStrainID(id); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if details.Flavors, err = s.client.GetStrainFlavorsByStrainID(id); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if details.Effects, err = s.client.GetStrainEffectsByStrainID(id); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, details)
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var results interface{}
+	var err error
+
+	switch {
+	case query.Get("name") != "":
+		results, err = s.client.SearchStrainsByName(query.Get("name"))
+	case query.Get("race") != "":
+		results, err = s.client.SearchStrainsByRace(strainapiclient.Race(query.Get("race")))
+	case query.Get("effect") != "":
+		results, err = s.client.SearchStrainsByEffectName(query.Get("effect"))
+	case query.Get("flavor") != "":
+		results, err = s.client.SearchStrainsByFlavor(strainapiclient.Flavor(query.Get("flavor")))
+	default:
+		writeRequestProblem(w, http.StatusBadRequest, CodeBadRequest, "Expected one of the name, race, effect, or flavor query parameters.")
+		return
+	}
+
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, results)
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
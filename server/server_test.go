@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tchype/strainapiclient-go"
+	"github.com/tchype/strainapiclient-go/strainapiclienttest"
+)
+
+func newTestProxy(t *testing.T) (*httptest.Server, func()) {
+	upstream := strainapiclienttest.NewServer(strainapiclienttest.DefaultFixtures())
+	proxy := httptest.NewServer(New(upstream.Client(), nil))
+
+	return proxy, func() {
+		proxy.Close()
+		upstream.Close()
+	}
+}
+
+func get(t *testing.T, url string, value interface{}) int {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Errorf("Problem calling %s: %s", url, err)
+		return 0
+	}
+	defer resp.Body.Close()
+
+	if value != nil {
+		if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+			t.Errorf("Problem parsing response from %s: %s", url, err)
+		}
+	}
+
+	return resp.StatusCode
+}
+
+func TestServerRoutes(t *testing.T) {
+	proxy, closeAll := newTestProxy(t)
+	defer closeAll()
+
+	strains := make(strainapiclient.ListAllStrainsResult)
+	if status := get(t, proxy.URL+"/strains", &strains); status != http.StatusOK || len(strains) != 4 {
+		t.Errorf("Expected 4 strains with status 200 but got %d strains with status %d", len(strains), status)
+	}
+
+	details := StrainDetails{Effects: make(strainapiclient.EffectsByEffectType)}
+	if status := get(t, proxy.URL+"/strains/2", &details); status != http.StatusOK || details.ID != 2 || len(details.Flavors) != 2 {
+		t.Errorf("Expected details for strain 2 but got %+v with status %d", details, status)
+	}
+
+	results := make(strainapiclient.SearchStrainsByRaceResults, 0)
+	if status := get(t, proxy.URL+"/search?race=indica", &results); status != http.StatusOK || len(results) != 1 {
+		t.Errorf("Expected 1 indica strain but got %v with status %d", results, status)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	proxy, closeAll := newTestProxy(t)
+	defer closeAll()
+
+	expectedStatuses := map[string]int{
+		"/strains/99":      http.StatusNotFound,
+		"/strains/abc":     http.StatusBadRequest,
+		"/search":          http.StatusBadRequest,
+		"/something-else":  http.StatusNotFound,
+		"/search?name=Zzz": http.StatusOK,
+	}
+
+	for path, expected := range expectedStatuses {
+		if status := get(t, proxy.URL+path, nil); status != expected {
+			t.Errorf("Expected status %d for %s but got %d", expected, path, status)
+		}
+	}
+}
+
+func TestServerErrorCodes(t *testing.T) {
+	proxy, closeAll := newTestProxy(t)
+	defer closeAll()
+
+	problem := Problem{}
+	get(t, proxy.URL+"/strains/99", &problem)
+
+	if problem.Status != http.StatusNotFound || problem.Code != strainapiclient.CodeNotFound {
+		t.Errorf("Expected a not found problem but got %+v", problem)
+	}
+}
+
+func TestServerHidesAPIKey(t *testing.T) {
+	upstream := strainapiclienttest.NewServer(strainapiclienttest.DefaultFixtures())
+	upstream.Close()
+	proxy := httptest.NewServer(New(upstream.Client(), nil))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL + "/strains")
+	if err != nil {
+		t.Errorf("Problem calling the proxy: %s", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusBadGateway || strings.Contains(string(body), strainapiclienttest.TestAPIKey) {
+		t.Errorf("Expected a 502 without the API Key but got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestServerProblemsCarryCorrelationID(t *testing.T) {
+	proxy, closeAll := newTestProxy(t)
+	defer closeAll()
+
+	request, _ := http.NewRequest(http.MethodGet, proxy.URL+"/unknown", nil)
+	request.Header.Set(CorrelationIDHeader, "abc-123")
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Errorf("Problem calling the proxy: %s", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	problem := Problem{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	if resp.Header.Get("Content-Type") != ProblemContentType || problem.Code != CodeUnknownRoute || problem.CorrelationID != "abc-123" {
+		t.Errorf("Expected an unknown route problem with correlation ID abc-123 but got %+v", problem)
+	}
+
+	resp, err = http.Get(proxy.URL + "/strains")
+	if err != nil {
+		t.Errorf("Problem calling the proxy: %s", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.Header.Get(CorrelationIDHeader) == "" {
+		t.Error("Expected a generated correlation ID on a successful response")
+	}
+}
@@ -0,0 +1,143 @@
+package strainapiclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SLOConfig describes a service level objective for requests to the API:
+// the fraction of requests that must succeed within LatencyTarget over a
+// rolling Window.  An Objective of 0.99 with a LatencyTarget of 500ms is a
+// p99 latency target of 500ms with a 1% error budget.
+type SLOConfig struct {
+	// LatencyTarget is the latency above which a successful request counts
+	// against the budget (0 counts only failures).
+	LatencyTarget time.Duration
+	// Objective is the fraction of requests, between 0 and 1, that must be good.
+	Objective float64
+	// Window is how far back requests are counted.
+	Window time.Duration
+	// BurnRateThreshold is the burn rate at which OnBurn is called (0 disables it).
+	BurnRateThreshold float64
+	// OnBurn is called when the burn rate rises to BurnRateThreshold or above.
+	// It is called again only after the burn rate drops back below it.
+	OnBurn func(status SLOStatus)
+}
+
+// SLOStatus is the state of an SLO over its window.
+type SLOStatus struct {
+	// Requests and BadRequests are the requests counted in the window.
+	Requests    int
+	BadRequests int
+	// BurnRate is how fast the error budget is being consumed: 1 means it
+	// will be used up exactly at the end of the window, 2 twice as fast.
+	BurnRate float64
+	// BudgetRemaining is the fraction of the error budget left in the
+	// window; it is negative once the budget is overspent.
+	BudgetRemaining float64
+}
+
+// sloEvent is a request counted by an SLOTracker.
+type sloEvent struct {
+	at  time.Time
+	bad bool
+}
+
+// SLOTracker tracks an SLO from the requests made through the handlers
+// it wraps (or recorded with Record), exposing the burn rate of the error
+// budget and calling SLOConfig.OnBurn when it is being consumed too fast.
+type SLOTracker struct {
+	config SLOConfig
+	now    func() time.Time
+
+	mutex   sync.Mutex
+	events  []sloEvent
+	burning bool
+}
+
+// NewSLOTracker creates a new SLOTracker for config.
+func NewSLOTracker(config SLOConfig) *SLOTracker {
+	return &SLOTracker{config: config, now: time.Now, events: make([]sloEvent, 0)}
+}
+
+// Wrap returns a HandleResourceRequestFunc that records the requests made
+// through next.
+func (t *SLOTracker) Wrap(next HandleResourceRequestFunc) HandleResourceRequestFunc {
+	return func(resourcePath string) ([]byte, error) {
+		start := time.Now()
+		body, err := next(resourcePath)
+		t.Record(time.Since(start), err)
+		return body, err
+	}
+}
+
+// WrapContext returns a HandleResourceRequestContextFunc that records the
+// requests made through next.
+func (t *SLOTracker) WrapContext(next HandleResourceRequestContextFunc) HandleResourceRequestContextFunc {
+	return func(ctx context.Context, resourcePath string) ([]byte, error) {
+		start := time.Now()
+		body, err := next(ctx, resourcePath)
+		t.Record(time.Since(start), err)
+		return body, err
+	}
+}
+
+// Record counts a request that took latency and failed with err (or nil).
+func (t *SLOTracker) Record(latency time.Duration, err error) {
+	bad := err != nil || (t.config.LatencyTarget > 0 && latency > t.config.LatencyTarget)
+
+	t.mutex.Lock()
+	t.events = append(t.events, sloEvent{at: t.now(), bad: bad})
+	status := t.statusLocked()
+
+	notify := false
+	if t.config.BurnRateThreshold > 0 {
+		burning := status.BurnRate >= t.config.BurnRateThreshold
+		notify = burning && !t.burning
+		t.burning = burning
+	}
+	t.mutex.Unlock()
+
+	if notify && t.config.OnBurn != nil {
+		t.config.OnBurn(status)
+	}
+}
+
+// Status returns the current SLOStatus.
+func (t *SLOTracker) Status() SLOStatus {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.statusLocked()
+}
+
+// statusLocked drops events outside the window and computes the status.
+// The mutex must be held.
+func (t *SLOTracker) statusLocked() SLOStatus {
+	cutoff := t.now().Add(-t.config.Window)
+
+	kept := 0
+	for kept < len(t.events) && t.events[kept].at.Before(cutoff) {
+		kept++
+	}
+	t.events = t.events[kept:]
+
+	status := SLOStatus{Requests: len(t.events), BudgetRemaining: 1}
+	for _, event := range t.events {
+		if event.bad {
+			status.BadRequests++
+		}
+	}
+
+	budget := 1 - t.config.Objective
+	if status.Requests == 0 || budget <= 0 {
+		return status
+	}
+
+	badFraction := float64(status.BadRequests) / float64(status.Requests)
+	status.BurnRate = badFraction / budget
+	status.BudgetRemaining = 1 - status.BurnRate
+
+	return status
+}
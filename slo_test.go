@@ -0,0 +1,48 @@
+package strainapiclient
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSLOTrackerBurnRate(t *testing.T) {
+	burns := 0
+	tracker := NewSLOTracker(SLOConfig{
+		LatencyTarget:     100 * time.Millisecond,
+		Objective:         0.9,
+		Window:            time.Minute,
+		BurnRateThreshold: 2,
+		OnBurn:            func(status SLOStatus) { burns++ },
+	})
+
+	for i := 0; i < 8; i++ {
+		tracker.Record(10*time.Millisecond, nil)
+	}
+	tracker.Record(200*time.Millisecond, nil)
+	tracker.Record(10*time.Millisecond, errors.New("Failed"))
+
+	status := tracker.Status()
+	if status.Requests != 10 || status.BadRequests != 2 || math.Abs(status.BurnRate-2) > 1e-9 {
+		t.Errorf("Expected 2 of 10 bad requests and a burn rate of 2 but got %+v", status)
+	}
+
+	if burns != 1 {
+		t.Errorf("Expected OnBurn to be called once but it was called %d times", burns)
+	}
+}
+
+func TestSLOTrackerWindow(t *testing.T) {
+	now := time.Now()
+	tracker := NewSLOTracker(SLOConfig{Objective: 0.99, Window: time.Minute})
+	tracker.now = func() time.Time { return now }
+
+	tracker.Record(0, errors.New("Failed"))
+	now = now.Add(2 * time.Minute)
+	tracker.Record(0, nil)
+
+	if status := tracker.Status(); status.Requests != 1 || status.BadRequests != 0 || status.BudgetRemaining != 1 {
+		t.Errorf("Expected the old failure to fall out of the window but got %+v", status)
+	}
+}
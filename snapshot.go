@@ -0,0 +1,79 @@
+package strainapiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Snapshot is a copy of the entire dataset: every effect, flavor, and strain
+// (with its description, race, flavors, and effects).
+type Snapshot struct {
+	Effects []Effect             `json:"effects"`
+	Flavors []Flavor             `json:"flavors"`
+	Strains ListAllStrainsResult `json:"strains"`
+}
+
+// TakeSnapshot downloads the entire dataset through client.  The API
+// doesn't list descriptions, so each strain is hydrated (see HydrateStrain)
+// with at most DefaultBatchConcurrency strains being hydrated at once.
+func TakeSnapshot(client Client) (Snapshot, error) {
+	return takeSnapshot(client, func(strains ListAllStrainsResult) (ListAllStrainsResult, error) {
+		return hydrateStrains(context.Background(), client, strains, DefaultBatchConcurrency)
+	})
+}
+
+// takeSnapshot downloads the entire dataset through client, filling in the
+// listed strains with hydrate.
+func takeSnapshot(client Client, hydrate func(strains ListAllStrainsResult) (ListAllStrainsResult, error)) (Snapshot, error) {
+	snapshot := Snapshot{}
+	var err error
+
+	if snapshot.Effects, err = client.ListAllEffects(); err != nil {
+		return snapshot, fmt.Errorf("Problem listing effects for snapshot: %w", err)
+	}
+
+	if snapshot.Flavors, err = client.ListAllFlavors(); err != nil {
+		return snapshot, fmt.Errorf("Problem listing flavors for snapshot: %w", err)
+	}
+
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return snapshot, fmt.Errorf("Problem listing strains for snapshot: %w", err)
+	}
+
+	if snapshot.Strains, err = hydrate(strains); err != nil {
+		return snapshot, fmt.Errorf("Problem hydrating strains for snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// LoadSnapshot reads a Snapshot written by Snapshot.Write from r.
+func LoadSnapshot(r io.Reader) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	if marshallErr := json.NewDecoder(r).Decode(&snapshot); marshallErr != nil {
+		return snapshot, fmt.Errorf("Problem parsing snapshot: %w", marshallErr)
+	}
+
+	if snapshot.Strains == nil {
+		snapshot.Strains = make(ListAllStrainsResult)
+	}
+	populateStrainNames(snapshot.Strains)
+
+	return snapshot, nil
+}
+
+// Write writes the Snapshot to w as JSON.
+func (s Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("Problem writing snapshot: %w", err)
+	}
+
+	return nil
+}
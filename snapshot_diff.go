@@ -0,0 +1,79 @@
+package strainapiclient
+
+import "sort"
+
+// StrainChange describes the field-level changes to one strain present in
+// both snapshots passed to Diff.  Name is the strain's name in the newer
+// snapshot.
+type StrainChange struct {
+	ID      int                 `json:"id"`
+	Name    string              `json:"name"`
+	Changes []StrainFieldChange `json:"changes"`
+}
+
+// SnapshotDiff is the difference between two snapshots.  Each list is
+// ordered by strain ID.
+type SnapshotDiff struct {
+	Added   []Strain       `json:"added"`
+	Removed []Strain       `json:"removed"`
+	Changed []StrainChange `json:"changed"`
+}
+
+// Empty reports whether the two snapshots had the same strains.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the strains of two snapshots by ID and returns the strains
+// that were added, removed, or changed (description, race, flavors, or
+// effects), so a downstream copy can be updated incrementally instead of
+// reloaded.
+func Diff(oldSnapshot Snapshot, newSnapshot Snapshot) SnapshotDiff {
+	diff := SnapshotDiff{Added: make([]Strain, 0), Removed: make([]Strain, 0), Changed: make([]StrainChange, 0)}
+
+	oldStrains := strainsByID(oldSnapshot.Strains)
+	newStrains := strainsByID(newSnapshot.Strains)
+
+	for id, newStrain := range newStrains {
+		oldStrain, exists := oldStrains[id]
+		if !exists {
+			diff.Added = append(diff.Added, newStrain)
+			continue
+		}
+
+		if oldStrain.Hash() == newStrain.Hash() {
+			continue
+		}
+
+		if changes := diffStrainFields(oldStrain, newStrain); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, StrainChange{ID: id, Name: newStrain.Name, Changes: changes})
+		}
+	}
+
+	for id, oldStrain := range oldStrains {
+		if _, exists := newStrains[id]; !exists {
+			diff.Removed = append(diff.Removed, oldStrain)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].ID < diff.Changed[j].ID })
+
+	return diff
+}
+
+// strainsByID re-keys strains by ID, filling in Name from the key when it
+// is empty.
+func strainsByID(strains ListAllStrainsResult) map[int]Strain {
+	byID := make(map[int]Strain)
+
+	for name, strain := range strains {
+		if strain.Name == "" {
+			strain.Name = name
+		}
+		byID[strain.ID] = strain
+	}
+
+	return byID
+}
@@ -0,0 +1,46 @@
+package strainapiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldSnapshot := Snapshot{Strains: ListAllStrainsResult{
+		"Kept":    {ID: 1, Race: RaceIndica, Flavors: []Flavor{"Pine"}},
+		"Changed": {ID: 2, Description: "Old", Race: RaceSativa, Flavors: []Flavor{"Lemon", "Lime"}, Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}},
+		"Removed": {ID: 3, Race: RaceHybrid},
+	}}
+	newSnapshot := Snapshot{Strains: ListAllStrainsResult{
+		"Kept":    {ID: 1, Race: RaceIndica, Flavors: []Flavor{"Pine"}},
+		"Changed": {ID: 2, Description: "New", Race: RaceSativa, Flavors: []Flavor{"Lime", "Mint"}, Effects: map[EffectType][]string{EffectTypePositive: {"Happy", "Focused"}}},
+		"Added":   {ID: 4, Race: RaceIndica},
+	}}
+
+	diff := Diff(oldSnapshot, newSnapshot)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != 4 || diff.Added[0].Name != "Added" {
+		t.Errorf("Expected strain 4 named Added to be added but got %v", diff.Added)
+	}
+
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != 3 || diff.Removed[0].Name != "Removed" {
+		t.Errorf("Expected strain 3 named Removed to be removed but got %v", diff.Removed)
+	}
+
+	expected := []StrainChange{{ID: 2, Name: "Changed", Changes: []StrainFieldChange{
+		{Field: StrainFieldDescription, Old: "Old", New: "New"},
+		{Field: StrainFieldFlavors, Added: []string{"Mint"}, Removed: []string{"Lemon"}},
+		{Field: StrainFieldPositiveEffects, Added: []string{"Focused"}, Removed: make([]string, 0)},
+	}}}
+	if !reflect.DeepEqual(diff.Changed, expected) {
+		t.Errorf("Expected changes %v but got %v", expected, diff.Changed)
+	}
+}
+
+func TestDiffIdenticalSnapshots(t *testing.T) {
+	snapshot := newFakeSnapshot()
+
+	if diff := Diff(snapshot, snapshot); !diff.Empty() {
+		t.Errorf("Expected no differences between identical snapshots but got %v", diff)
+	}
+}
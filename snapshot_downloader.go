@@ -0,0 +1,181 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrSnapshotCorrupt is returned when a snapshot file doesn't match the
+// SHA-256 checksum in its manifest.
+var ErrSnapshotCorrupt = newCodedError(CodeSnapshotCorrupt, "Snapshot does not match its checksum")
+
+// snapshotManifestSuffix ends the name of every snapshot manifest file.
+const snapshotManifestSuffix string = ".manifest.json"
+
+// SnapshotManifest describes a snapshot file written by a
+// SnapshotDownloader.  File is relative to the manifest's directory.
+type SnapshotManifest struct {
+	Version   string    `json:"version"`
+	File      string    `json:"file"`
+	SHA256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"createdAt"`
+	Strains   int       `json:"strains"`
+}
+
+// SnapshotDownloader downloads the entire dataset into versioned snapshot
+// files in a directory, each with a manifest holding its SHA-256 checksum,
+// so offline clients and caches can check a snapshot's integrity before
+// trusting it.
+type SnapshotDownloader struct {
+	client Client
+	dir    string
+	now    func() time.Time
+}
+
+// NewSnapshotDownloader creates a new SnapshotDownloader that downloads
+// through client into dir.
+func NewSnapshotDownloader(client Client, dir string) *SnapshotDownloader {
+	return &SnapshotDownloader{client: client, dir: dir, now: time.Now}
+}
+
+// Download takes a snapshot, with every strain's description (see
+// TakeSnapshot), and writes it to snapshot-<version>.json with
+// its manifest in snapshot-<version>.manifest.json, where the version is
+// the UTC time of the download.  The manifest is written last, so a
+// snapshot is only listed once it is complete.
+func (d *SnapshotDownloader) Download() (SnapshotManifest, error) {
+	snapshot, err := TakeSnapshot(d.client)
+	if err != nil {
+		return SnapshotManifest{}, err
+	}
+
+	var buffer bytes.Buffer
+	if err := snapshot.Write(&buffer); err != nil {
+		return SnapshotManifest{}, err
+	}
+
+	createdAt := d.now().UTC()
+	version := createdAt.Format("20060102T150405.000000000Z")
+	checksum := sha256.Sum256(buffer.Bytes())
+
+	manifest := SnapshotManifest{
+		Version:   version,
+		File:      "snapshot-" + version + ".json",
+		SHA256:    hex.EncodeToString(checksum[:]),
+		CreatedAt: createdAt,
+		Strains:   len(snapshot.Strains),
+	}
+
+	if err := writeFileAtomically(filepath.Join(d.dir, manifest.File), buffer.Bytes()); err != nil {
+		return manifest, err
+	}
+
+	manifestJSON, marshallErr := json.MarshalIndent(manifest, "", "  ")
+	if marshallErr != nil {
+		return manifest, fmt.Errorf("Problem encoding snapshot manifest: %w", marshallErr)
+	}
+
+	manifestPath := filepath.Join(d.dir, "snapshot-"+version+snapshotManifestSuffix)
+	if err := writeFileAtomically(manifestPath, manifestJSON); err != nil {
+		return manifest, err
+	}
+
+	return manifest, nil
+}
+
+// Latest returns the path of the manifest of the newest snapshot in the
+// directory, or an error wrapping ErrNotFound if there are none.
+func (d *SnapshotDownloader) Latest() (string, error) {
+	manifests, err := filepath.Glob(filepath.Join(d.dir, "snapshot-*"+snapshotManifestSuffix))
+	if err != nil {
+		return "", fmt.Errorf("Problem listing snapshots in %s: %w", d.dir, err)
+	}
+
+	if len(manifests) == 0 {
+		return "", fmt.Errorf("No snapshots in %s: %w", d.dir, ErrNotFound)
+	}
+
+	// Versions are fixed-width UTC times, so they sort chronologically
+	sort.Strings(manifests)
+	return manifests[len(manifests)-1], nil
+}
+
+// LoadVerifiedSnapshot reads the manifest at manifestPath and the snapshot
+// it describes, returning an error wrapping ErrSnapshotCorrupt if the
+// snapshot doesn't match the manifest's checksum or the manifest names a
+// file outside its own directory.
+func LoadVerifiedSnapshot(manifestPath string) (Snapshot, SnapshotManifest, error) {
+	manifest := SnapshotManifest{}
+
+	manifestJSON, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return Snapshot{}, manifest, fmt.Errorf("Problem reading snapshot manifest %s: %w", manifestPath, err)
+	}
+
+	if marshallErr := json.Unmarshal(manifestJSON, &manifest); marshallErr != nil {
+		return Snapshot{}, manifest, fmt.Errorf("Problem parsing snapshot manifest %s: %w", manifestPath, marshallErr)
+	}
+
+	if filepath.IsAbs(manifest.File) || containsParentElement(manifest.File) {
+		return Snapshot{}, manifest, fmt.Errorf("Snapshot manifest %s names a file outside its directory, %s: %w", manifestPath, manifest.File, ErrSnapshotCorrupt)
+	}
+
+	snapshotPath := filepath.Join(filepath.Dir(manifestPath), manifest.File)
+	contents, err := ioutil.ReadFile(snapshotPath)
+	if err != nil {
+		return Snapshot{}, manifest, fmt.Errorf("Problem reading snapshot %s: %w", snapshotPath, err)
+	}
+
+	checksum := sha256.Sum256(contents)
+	if !strings.EqualFold(hex.EncodeToString(checksum[:]), manifest.SHA256) {
+		return Snapshot{}, manifest, fmt.Errorf("Snapshot %s: %w", snapshotPath, ErrSnapshotCorrupt)
+	}
+
+	snapshot, err := LoadSnapshot(bytes.NewReader(contents))
+	return snapshot, manifest, err
+}
+
+// containsParentElement returns whether path has a ".." element.
+func containsParentElement(path string) bool {
+	for _, element := range strings.Split(filepath.ToSlash(path), "/") {
+		if element == ".." {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeFileAtomically writes contents to path through a temporary file in
+// the same directory, so readers never see a partial file.
+func writeFileAtomically(path string, contents []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return fmt.Errorf("Problem creating %s: %w", path, err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(contents)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("Problem writing %s: %w", path, err)
+	}
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("Problem writing %s: %w", path, err)
+	}
+
+	return nil
+}
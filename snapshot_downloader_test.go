@@ -0,0 +1,100 @@
+package strainapiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotDownloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-snapshots")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	downloader := NewSnapshotDownloader(NewSnapshotClient(newFakeSnapshot()), dir)
+
+	if _, err := downloader.Latest(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound with no snapshots but got %v", err)
+	}
+
+	now := time.Date(2020, 4, 20, 16, 20, 0, 0, time.UTC)
+	downloader.now = func() time.Time { return now }
+	downloader.Download()
+
+	now = now.Add(time.Hour)
+	manifest, err := downloader.Download()
+	if err != nil || manifest.Strains != 20 {
+		t.Errorf("Expected a manifest for 20 strains but got %+v and %v", manifest, err)
+	}
+
+	latest, err := downloader.Latest()
+	if err != nil {
+		t.Errorf("Expected no error finding the latest snapshot but got: %s", err)
+	}
+
+	snapshot, loadedManifest, err := LoadVerifiedSnapshot(latest)
+	if err != nil || loadedManifest.Version != manifest.Version || len(snapshot.Strains) != 20 {
+		t.Errorf("Expected the latest snapshot %s but got %+v and %v", manifest.Version, loadedManifest, err)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, manifest.File), []byte("{}"), 0644)
+	if _, _, err := LoadVerifiedSnapshot(latest); !errors.Is(err, ErrSnapshotCorrupt) {
+		t.Errorf("Expected ErrSnapshotCorrupt for a modified snapshot but got %v", err)
+	}
+}
+
+func TestLoadVerifiedSnapshotRejectsOutsideFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-snapshots")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	manifestPath := filepath.Join(dir, "manifest.json")
+	for _, file := range []string{"../snapshot.json", "nested/../../snapshot.json", filepath.Join(dir, "snapshot.json")} {
+		ioutil.WriteFile(manifestPath, []byte(`{"file": "`+filepath.ToSlash(file)+`"}`), 0644)
+
+		if _, _, err := LoadVerifiedSnapshot(manifestPath); !errors.Is(err, ErrSnapshotCorrupt) {
+			t.Errorf("Expected ErrSnapshotCorrupt for a manifest naming %s but got %v", file, err)
+		}
+	}
+}
+
+func TestSnapshotDownloaderHydratesDescriptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "strainapiclient-snapshots")
+	if err != nil {
+		t.Errorf("Problem creating temp dir: %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"], \"effects\": {}}}"), nil
+		case strings.Contains(path, "/strains/data/desc/1"):
+			return []byte("{\"desc\": \"Earthy and calm\"}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	downloader := NewSnapshotDownloader(client, dir)
+	if _, err := downloader.Download(); err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	latest, _ := downloader.Latest()
+	snapshot, _, err := LoadVerifiedSnapshot(latest)
+	if err != nil || snapshot.Strains["Afpak"].Description != "Earthy and calm" {
+		t.Errorf("Expected the downloaded snapshot to have descriptions but got %v (error: %v)", snapshot.Strains, err)
+	}
+}
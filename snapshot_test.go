@@ -0,0 +1,82 @@
+package strainapiclient
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newFakeSnapshot() Snapshot {
+	generator := NewFakeDatasetGenerator(1)
+	return Snapshot{Effects: generator.Effects(), Flavors: generator.Flavors(), Strains: generator.Strains(20)}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	expected := newFakeSnapshot()
+
+	snapshot, err := TakeSnapshot(NewSnapshotClient(expected))
+	if err != nil {
+		t.Errorf("Expected no error taking snapshot but got: %s", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := snapshot.Write(&buffer); err != nil {
+		t.Errorf("Expected no error writing snapshot but got: %s", err)
+	}
+
+	loaded, err := LoadSnapshot(&buffer)
+	if err != nil {
+		t.Errorf("Expected no error loading snapshot but got: %s", err)
+	}
+
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Error("Expected the loaded snapshot to match the original")
+	}
+}
+
+func TestTakeSnapshotError(t *testing.T) {
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 500}
+	})
+
+	if _, err := TakeSnapshot(client); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError but got %v", err)
+	}
+}
+
+func TestTakeSnapshotHydratesDescriptions(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"], \"effects\": {\"positive\": [\"Happy\"]}}," +
+				"\"Blue\": {\"id\": 2, \"race\": \"indica\", \"flavors\": [], \"effects\": {}}}"), nil
+		case strings.Contains(path, "/strains/data/desc/1"):
+			return []byte("{\"desc\": \"Earthy and calm\"}"), nil
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte("{}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	snapshot, err := TakeSnapshot(client)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if description := snapshot.Strains["Afpak"].Description; description != "Earthy and calm" {
+		t.Errorf("Expected the description to be fetched but got '%s'", description)
+	}
+
+	if len(snapshot.Strains) != 2 || snapshot.Strains["Blue"].Description != "" {
+		t.Errorf("Expected both strains, Blue without a description, but got %v", snapshot.Strains)
+	}
+
+	offline := NewSnapshotClient(snapshot)
+	if description, err := offline.GetStrainDescriptionByStrainID(1); err != nil || description != "Earthy and calm" {
+		t.Errorf("Expected the snapshot to serve the description but got '%s' (error: %v)", description, err)
+	}
+}
@@ -0,0 +1,189 @@
+// Package soaktest runs a workload for a long time while sampling heap and
+// goroutine counts, failing when either grows steadily — the signature of a
+// leak in long-lived background work such as watchers, schedulers, and caches.
+package soaktest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrGoroutineLeak is returned when the goroutine count grows steadily over a run.
+var ErrGoroutineLeak = errors.New("goroutine count grew steadily during the soak test")
+
+// ErrHeapGrowth is returned when the live heap grows steadily over a run.
+var ErrHeapGrowth = errors.New("heap grew steadily during the soak test")
+
+// ErrInvalidConfig is returned when a Config can't be run.
+var ErrInvalidConfig = errors.New("invalid soak test config")
+
+// Config controls a soak test run.
+type Config struct {
+	// Workload is called repeatedly until Duration has passed; it must be set.
+	Workload func(ctx context.Context) error
+	// Concurrency is the number of goroutines calling Workload (default 1).
+	Concurrency int
+	// Duration is how long the soak test runs; it must be positive.
+	Duration time.Duration
+	// SampleInterval is how often heap and goroutine counts are sampled
+	// (default Duration / 20).
+	SampleInterval time.Duration
+	// Windows is how many consecutive windows the samples are split into
+	// when looking for growth (default 4).  Growth is only reported when
+	// every window is larger than the one before it.
+	Windows int
+	// Tolerance is the relative growth between windows that is ignored
+	// (default 0.05, i.e. 5%).
+	Tolerance float64
+}
+
+// Sample is a single measurement taken during a soak test.
+type Sample struct {
+	Elapsed    time.Duration
+	Goroutines int
+	HeapAlloc  uint64
+}
+
+// Report is the result of a soak test run.
+type Report struct {
+	Samples    []Sample
+	Iterations int
+	Errors     int
+}
+
+// Run executes the soak test described by config.  It returns ErrGoroutineLeak
+// or ErrHeapGrowth (wrapped with the sampled values) when growth is detected,
+// and ErrInvalidConfig when config has no Workload or its Duration isn't
+// positive.
+func Run(ctx context.Context, config Config) (Report, error) {
+	if config.Workload == nil {
+		return Report{Samples: make([]Sample, 0)}, fmt.Errorf("Workload must be set: %w", ErrInvalidConfig)
+	}
+	if config.Duration <= 0 {
+		return Report{Samples: make([]Sample, 0)}, fmt.Errorf("Duration must be positive but is %s: %w", config.Duration, ErrInvalidConfig)
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.SampleInterval <= 0 {
+		config.SampleInterval = config.Duration / 20
+	}
+	if config.SampleInterval <= 0 {
+		config.SampleInterval = config.Duration
+	}
+	if config.Windows < 2 {
+		config.Windows = 4
+	}
+	if config.Tolerance <= 0 {
+		config.Tolerance = 0.05
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	report := Report{Samples: make([]Sample, 0)}
+	var reportMutex sync.Mutex
+	var workers sync.WaitGroup
+
+	for worker := 0; worker < config.Concurrency; worker++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for ctx.Err() == nil {
+				err := config.Workload(ctx)
+
+				reportMutex.Lock()
+				report.Iterations++
+				if err != nil && ctx.Err() == nil {
+					report.Errors++
+				}
+				reportMutex.Unlock()
+			}
+		}()
+	}
+
+	started := time.Now()
+	ticker := time.NewTicker(config.SampleInterval)
+
+	for sampling := true; sampling; {
+		select {
+		case <-ctx.Done():
+			sampling = false
+		case <-ticker.C:
+			report.Samples = append(report.Samples, takeSample(started))
+		}
+	}
+
+	ticker.Stop()
+	workers.Wait()
+
+	goroutines := make([]float64, len(report.Samples))
+	heap := make([]float64, len(report.Samples))
+	for index, sample := range report.Samples {
+		goroutines[index] = float64(sample.Goroutines)
+		heap[index] = float64(sample.HeapAlloc)
+	}
+
+	if growsSteadily(goroutines, config.Windows, config.Tolerance) {
+		return report, fmt.Errorf("%w: window averages %v", ErrGoroutineLeak, windowAverages(goroutines, config.Windows))
+	}
+
+	if growsSteadily(heap, config.Windows, config.Tolerance) {
+		return report, fmt.Errorf("%w: window averages %v", ErrHeapGrowth, windowAverages(heap, config.Windows))
+	}
+
+	return report, nil
+}
+
+func takeSample(started time.Time) Sample {
+	runtime.GC()
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	return Sample{
+		Elapsed:    time.Since(started),
+		Goroutines: runtime.NumGoroutine(),
+		HeapAlloc:  memStats.HeapAlloc,
+	}
+}
+
+// growsSteadily reports whether every window of values averages more than
+// the window before it by at least the tolerance.
+func growsSteadily(values []float64, windows int, tolerance float64) bool {
+	if len(values) < windows {
+		return false
+	}
+
+	averages := windowAverages(values, windows)
+	for index := 1; index < len(averages); index++ {
+		if averages[index] <= averages[index-1]*(1+tolerance) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func windowAverages(values []float64, windows int) []float64 {
+	averages := make([]float64, windows)
+	size := len(values) / windows
+	if size == 0 {
+		return averages
+	}
+
+	for window := 0; window < windows; window++ {
+		sum := 0.0
+		for _, value := range values[window*size : (window+1)*size] {
+			sum += value
+		}
+		averages[window] = sum / float64(size)
+	}
+
+	return averages
+}
@@ -0,0 +1,75 @@
+package soaktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunWithoutLeak(t *testing.T) {
+	report, err := Run(context.Background(), Config{
+		Workload: func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		},
+		Duration:       200 * time.Millisecond,
+		SampleInterval: 10 * time.Millisecond,
+	})
+
+	if err != nil {
+		t.Errorf("Expected no leak but got: %s", err)
+	}
+
+	if report.Iterations == 0 || len(report.Samples) == 0 {
+		t.Errorf("Expected iterations and samples but got %d iterations and %d samples", report.Iterations, len(report.Samples))
+	}
+}
+
+func TestRunDetectsGoroutineLeak(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	_, err := Run(context.Background(), Config{
+		Workload: func(ctx context.Context) error {
+			go func() { <-release }()
+			time.Sleep(time.Millisecond)
+			return nil
+		},
+		Duration:       200 * time.Millisecond,
+		SampleInterval: 10 * time.Millisecond,
+	})
+
+	if !errors.Is(err, ErrGoroutineLeak) {
+		t.Errorf("Expected ErrGoroutineLeak but got: %v", err)
+	}
+}
+
+func TestRunRejectsNonPositiveDuration(t *testing.T) {
+	_, err := Run(context.Background(), Config{
+		Workload: func(ctx context.Context) error { return nil },
+	})
+
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig but got: %v", err)
+	}
+}
+
+func TestRunRejectsMissingWorkload(t *testing.T) {
+	_, err := Run(context.Background(), Config{Duration: time.Second})
+
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig but got: %v", err)
+	}
+}
+
+func TestRunFloorsSampleInterval(t *testing.T) {
+	_, err := Run(context.Background(), Config{
+		Workload: func(ctx context.Context) error { return nil },
+		Duration: 10 * time.Nanosecond,
+	})
+
+	if err != nil && !errors.Is(err, ErrGoroutineLeak) && !errors.Is(err, ErrHeapGrowth) {
+		t.Errorf("Expected a tiny Duration to run but got: %v", err)
+	}
+}
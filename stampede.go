@@ -0,0 +1,41 @@
+package strainapiclient
+
+// inflightFetch is a fetch for a cache key that other callers missing the
+// same key wait on instead of fetching again.
+type inflightFetch struct {
+	done       chan struct{}
+	resultJSON []byte
+	err        error
+}
+
+// fetchOnce calls fetch for key unless a fetch for key is already running,
+// in which case it waits for that fetch and returns its result.  It traces
+// a DecisionCacheMiss with reason for the fetch or a DecisionSharedFetch
+// for the wait.  This keeps
+// a popular entry (like the list of all strains) from being fetched by
+// every caller that misses it at once when it expires.
+func (c *CachingClient) fetchOnce(key string, reason string, fetch func() ([]byte, error)) ([]byte, error) {
+	c.inflightMutex.Lock()
+	if running, found := c.inflight[key]; found {
+		c.inflightMutex.Unlock()
+		c.decisions.emit(DecisionSharedFetch, "CachingClient", key, "waiting for a fetch already in progress")
+		<-running.done
+		return running.resultJSON, running.err
+	}
+
+	running := &inflightFetch{done: make(chan struct{})}
+	c.inflight[key] = running
+	c.inflightMutex.Unlock()
+
+	c.decisions.emit(DecisionCacheMiss, "CachingClient", key, reason)
+
+	defer func() {
+		c.inflightMutex.Lock()
+		delete(c.inflight, key)
+		c.inflightMutex.Unlock()
+		close(running.done)
+	}()
+
+	running.resultJSON, running.err = fetch()
+	return running.resultJSON, running.err
+}
@@ -0,0 +1,65 @@
+package strainapiclient
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachingClientFetchesExpiredEntryOnce(t *testing.T) {
+	var calls int32
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"]}}"), nil
+	})
+	cachingClient := NewCachingClient(client, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if strains, err := cachingClient.ListAllStrains(); err != nil || len(strains) != 1 {
+				t.Errorf("Expected 1 strain but got %v and error %v", strains, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call for concurrent misses but got %d", calls)
+	}
+}
+
+func TestCachingClientSharesFetchErrors(t *testing.T) {
+	var calls int32
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return make([]byte, 0), &APIError{StatusCode: 500}
+	})
+	cachingClient := NewCachingClient(client, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cachingClient.ListAllEffects(); err == nil {
+				t.Error("Expected the shared error but got none")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Errors aren't cached, so the next call fetches again
+	cachingClient.ListAllEffects()
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls but got %d", calls)
+	}
+}
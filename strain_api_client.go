@@ -3,15 +3,19 @@
 package strainapiclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 const baseURLHost string = "strainapi.evanbusse.com"
 const baseURL string = "https://" + baseURLHost
+const defaultUserAgent string = "strain-api-client-go/v1"
 
 // Client represents the interface a Client must implemenet
 type Client interface {
@@ -31,38 +35,134 @@ type Client interface {
 	SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc
 }
 
+// ContextClient is the context-aware counterpart of Client.  Every
+// method takes a context.Context as its first argument so callers can
+// cancel calls or apply deadlines to them.
+type ContextClient interface {
+	ListAllEffectsContext(ctx context.Context) ([]Effect, error)
+	ListAllFlavorsContext(ctx context.Context) ([]Flavor, error)
+	ListAllStrainsContext(ctx context.Context) (ListAllStrainsResult, error)
+	SearchStrainsByNameContext(ctx context.Context, name string) (SearchStrainsByNameResults, error)
+	SearchStrainsByRaceContext(ctx context.Context, race Race) (SearchStrainsByRaceResults, error)
+	SearchStrainsByFlavorContext(ctx context.Context, flavor Flavor) (SearchStrainsByFlavorResults, error)
+	SearchStrainsByEffectNameContext(ctx context.Context, effectName string) (SearchStrainsByEffectNameResults, error)
+	GetStrainDescriptionByStrainIDContext(ctx context.Context, id int) (string, error)
+	GetStrainFlavorsByStrainIDContext(ctx context.Context, id int) ([]Flavor, error)
+	GetStrainEffectsByStrainIDContext(ctx context.Context, id int) (EffectsByEffectType, error)
+}
+
 // HandleResourceRequestFunc is the signature of a function that can handle
 // a resource request to the client.
 type HandleResourceRequestFunc func(resourcePath string) ([]byte, error)
 
+// HandleResourceRequestContextFunc is the context-aware version of
+// HandleResourceRequestFunc.
+type HandleResourceRequestContextFunc func(ctx context.Context, resourcePath string) ([]byte, error)
+
 // DefaultClient is the default implementation of a Client for The Strain API
 type DefaultClient struct {
-	apiKey                     string
-	resourceRequestHandlerFunc HandleResourceRequestFunc
+	apiKey                            string
+	baseURL                           string
+	userAgent                         string
+	timeout                           time.Duration
+	adaptiveTimeouts                  *adaptiveTimeouts
+	retryPolicy                       RetryPolicy
+	conditionalCache                  Cache
+	aliases                           *AliasRegistry
+	faultInjector                     *FaultInjector
+	writeBehind                       *writeBehind
+	httpClient                        *http.Client
+	resourceRequestHandlerFunc        HandleResourceRequestFunc
+	resourceRequestHandlerContextFunc HandleResourceRequestContextFunc
 }
 
 // NewDefaultClient creates a new DefaultClient with the apiKey passed in.
 func NewDefaultClient(apiKey string) *DefaultClient {
-	client := &DefaultClient{apiKey: apiKey}
-	client.resourceRequestHandlerFunc = simpleHTTPGetForFullPath
+	return NewClient(apiKey)
+}
+
+// NewClient creates a new DefaultClient with the apiKey passed in,
+// configured by any Options passed after it.
+func NewClient(apiKey string, opts ...Option) *DefaultClient {
+	client := &DefaultClient{
+		apiKey:      apiKey,
+		baseURL:     baseURL,
+		userAgent:   defaultUserAgent,
+		httpClient:  &http.Client{},
+		retryPolicy: DefaultRetryPolicy,
+	}
+	client.resourceRequestHandlerFunc = client.simpleHTTPGetForFullPath
+	client.resourceRequestHandlerContextFunc = client.simpleHTTPGetForFullPathContext
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
 	return client
 }
 
 // SetHandleResourceRequestFunc sets a new request handler for the DefaultClient
 // (including any custom function that matches the HandleResrourceRequestFunc signature)
 // and returns the value that was previously used.
+//
+// Setting a HandleResourceRequestFunc replaces any HandleResourceRequestContextFunc,
+// so the context passed to the *Context methods is only checked before the
+// handler is called rather than propagated into it.
 func (c *DefaultClient) SetHandleResourceRequestFunc(f HandleResourceRequestFunc) HandleResourceRequestFunc {
 	current := c.resourceRequestHandlerFunc
 	c.resourceRequestHandlerFunc = f
+	c.resourceRequestHandlerContextFunc = nil
+	return current
+}
+
+// SetBaseURL sets the base URL all request paths are built from (for example
+// a staging mirror, a caching proxy, or an httptest.Server) and returns the
+// value that was previously used.  Any trailing '/' is removed.
+func (c *DefaultClient) SetBaseURL(baseURL string) string {
+	current := c.baseURL
+	c.baseURL = strings.TrimRight(baseURL, "/")
+	return current
+}
+
+// SetHTTPClient sets the *http.Client the default request handler uses for
+// every request to the API and returns the value that was previously used.
+// Passing nil restores a plain http.Client.
+func (c *DefaultClient) SetHTTPClient(httpClient *http.Client) *http.Client {
+	current := c.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	c.httpClient = httpClient
+	return current
+}
+
+// SetHandleResourceRequestContextFunc sets a new context-aware request handler
+// for the DefaultClient and returns the value that was previously used (which
+// is nil if a HandleResourceRequestFunc was set more recently).
+func (c *DefaultClient) SetHandleResourceRequestContextFunc(f HandleResourceRequestContextFunc) HandleResourceRequestContextFunc {
+	current := c.resourceRequestHandlerContextFunc
+	c.resourceRequestHandlerContextFunc = f
 	return current
 }
 
-// simpleHTTPGet is just a simple wrapper for getting basic
+// simpleHTTPGetContext is just a simple wrapper for getting basic
 // byte slices from an HTTP GET call.
 // It uses the base url of the API and appends the string
 // passed in to the path (you must add a leading '/').
-func (c *DefaultClient) simpleHTTPGet(restOfURLPath string) ([]byte, error) {
-	return c.resourceRequestHandlerFunc(baseURL + "/" + c.apiKey + restOfURLPath)
+// The context is passed to the HandleResourceRequestContextFunc when one
+// is set; otherwise it is checked before calling the HandleResourceRequestFunc.
+func (c *DefaultClient) simpleHTTPGetContext(ctx context.Context, restOfURLPath string) ([]byte, error) {
+	fullPath := c.baseURL + "/" + c.apiKey + restOfURLPath
+
+	if c.resourceRequestHandlerContextFunc != nil {
+		return c.resourceRequestHandlerContextFunc(ctx, fullPath)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return make([]byte, 0), err
+	}
+
+	return c.resourceRequestHandlerFunc(fullPath)
 }
 
 // simpleHTTPGetForFullPath is the default implementation of a
@@ -70,18 +170,72 @@ func (c *DefaultClient) simpleHTTPGet(restOfURLPath string) ([]byte, error) {
 // call to the DefaultClient's API.  You can override this
 // implementation by making your own HandleResourceReqeustFunc
 // and set it using the SetHandleResourceRequestFunc() function.
-func simpleHTTPGetForFullPath(path string) ([]byte, error) {
-	req, err := http.NewRequest("GET", path, nil)
-	req.Header.Set("Host", baseURLHost)
-	req.Header.Set("User-Agent", "strain-api-client-go/v1")
+func (c *DefaultClient) simpleHTTPGetForFullPath(path string) ([]byte, error) {
+	return c.simpleHTTPGetForFullPathContext(context.Background(), path)
+}
+
+// simpleHTTPGetForFullPathContext is the default implementation of a
+// HandleResourceRequestContextFunc.  The context is attached to the
+// outgoing HTTP request so cancelling it aborts the call.  If the context
+// was created with WithHedging, each attempt is hedged.
+func (c *DefaultClient) simpleHTTPGetForFullPathContext(ctx context.Context, path string) ([]byte, error) {
+	delay, hedging := hedgingDelay(ctx)
+
+	return c.retryPolicy.do(ctx, func() ([]byte, error) {
+		if hedging {
+			return hedge(ctx, delay, func(ctx context.Context) ([]byte, error) {
+				return c.httpGetOnce(ctx, path)
+			})
+		}
+
+		return c.httpGetOnce(ctx, path)
+	})
+}
+
+// httpGetOnce makes a single HTTP GET request for path, without retries.
+func (c *DefaultClient) httpGetOnce(ctx context.Context, path string) ([]byte, error) {
+	timeout := c.timeout
+	if c.adaptiveTimeouts != nil {
+		endpoint := adaptiveTimeoutEndpoint(strings.TrimPrefix(path, c.baseURL+"/"+c.apiKey))
+		timeout = c.adaptiveTimeouts.timeout(endpoint)
+
+		start := time.Now()
+		defer func() {
+			c.adaptiveTimeouts.observe(endpoint, time.Since(start))
+		}()
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if c.faultInjector != nil {
+		return c.faultInjector.inject(ctx, path, c.httpGet)
+	}
+
+	return c.httpGet(ctx, path)
+}
+
+// httpGet sends the GET request for path made by httpGetOnce.
+func (c *DefaultClient) httpGet(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return make([]byte, 0), fmt.Errorf("There was a problem creating the request: %w", err)
+	}
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("User-Agent", c.userAgent)
 
-	client := http.Client{
-		Timeout: 0,
+	validators, hasValidators := c.conditionalValidators(path)
+	if hasValidators {
+		validators.apply(req)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		specificError := fmt.Errorf("There was a problem connecting to the api: %s", err)
+		specificError := fmt.Errorf("There was a problem connecting to the api: %w", err)
 		return make([]byte, 0), specificError
 	}
 
@@ -89,23 +243,38 @@ func simpleHTTPGetForFullPath(path string) ([]byte, error) {
 
 	body, bodyErr := ioutil.ReadAll(resp.Body)
 
+	if resp.StatusCode == http.StatusNotModified && hasValidators {
+		return validators.Body, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return make([]byte, 0), fmt.Errorf("Status: %d - %s", resp.StatusCode, string(body))
+		return make([]byte, 0), &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
-	if bodyErr != nil || err != nil {
-		parsingError := fmt.Errorf("There was a problem reading the body of the response: %s", err)
+	if bodyErr != nil {
+		parsingError := fmt.Errorf("There was a problem reading the body of the response: %w", bodyErr)
 		return make([]byte, 0), parsingError
 	}
 
+	c.storeConditionalValidators(path, resp, body)
+
 	return body, nil
 }
 
 // CanConnect simply hits the root of the API with your API Key
 // and makes sure it gets back the default response from the API.
 func (c *DefaultClient) CanConnect() bool {
+	return c.CanConnectContext(context.Background())
+}
+
+// CanConnectContext is the context-aware version of CanConnect.
+func (c *DefaultClient) CanConnectContext(ctx context.Context) bool {
 	// Expected response: Seems legit to me man...
-	body, _ := c.simpleHTTPGet("")
+	body, _ := c.simpleHTTPGetContext(ctx, "")
 	return string(body) == "Seems legit to me man..."
 }
 
@@ -132,9 +301,14 @@ const (
 // ListAllEffects returns a slice of Effect elements that
 // represents all effects that can be experienced.
 func (c *DefaultClient) ListAllEffects() ([]Effect, error) {
+	return c.ListAllEffectsContext(context.Background())
+}
+
+// ListAllEffectsContext is the context-aware version of ListAllEffects.
+func (c *DefaultClient) ListAllEffectsContext(ctx context.Context) ([]Effect, error) {
 	effects := make([]Effect, 0)
 
-	allEffectsJSONBytes, err := c.simpleHTTPGet("/searchdata/effects")
+	allEffectsJSONBytes, err := c.simpleHTTPGetContext(ctx, "/searchdata/effects")
 	if err != nil {
 		return effects, err
 	}
@@ -149,9 +323,14 @@ type Flavor string
 // ListAllFlavors returns a slice of Flavor elements that
 // represents all flavors of a strain.
 func (c *DefaultClient) ListAllFlavors() ([]Flavor, error) {
+	return c.ListAllFlavorsContext(context.Background())
+}
+
+// ListAllFlavorsContext is the context-aware version of ListAllFlavors.
+func (c *DefaultClient) ListAllFlavorsContext(ctx context.Context) ([]Flavor, error) {
 	flavors := make([]Flavor, 0)
 
-	allFlavorsJSONBytes, err := c.simpleHTTPGet("/searchdata/flavors")
+	allFlavorsJSONBytes, err := c.simpleHTTPGetContext(ctx, "/searchdata/flavors")
 	if err != nil {
 		return flavors, err
 	}
@@ -173,6 +352,8 @@ const (
 )
 
 // Strain represents a single strain of cannabis and its properites.
+// Extensions holds supplemental data (such as THC% or pricing) merged in
+// by EnrichmentProviders; the API never populates it.
 type Strain struct {
 	Name        string                  `json:"name"`
 	ID          int                     `json:"id"`
@@ -180,6 +361,7 @@ type Strain struct {
 	Race        Race                    `json:"race"`
 	Flavors     []Flavor                `json:"flavors"`
 	Effects     map[EffectType][]string `json:"effects"`
+	Extensions  map[string]interface{}  `json:"extensions,omitempty"`
 }
 
 const strainsBasePath string = "/strains"
@@ -191,10 +373,15 @@ type ListAllStrainsResult map[string]Strain
 // ListAllStrains gets a ListAllStrainsResult of all strains
 // (please use sparingly, it is expensive to run).
 func (c *DefaultClient) ListAllStrains() (ListAllStrainsResult, error) {
+	return c.ListAllStrainsContext(context.Background())
+}
+
+// ListAllStrainsContext is the context-aware version of ListAllStrains.
+func (c *DefaultClient) ListAllStrainsContext(ctx context.Context) (ListAllStrainsResult, error) {
 	strainsResults := make(ListAllStrainsResult)
 
 	findAllURL := strainSearchBasePath + "/all"
-	strainsResultsJSONBytes, err := c.simpleHTTPGet(findAllURL)
+	strainsResultsJSONBytes, err := c.simpleHTTPGetContext(ctx, findAllURL)
 
 	if err != nil {
 		return strainsResults, err
@@ -204,6 +391,10 @@ func (c *DefaultClient) ListAllStrains() (ListAllStrainsResult, error) {
 
 	populateStrainNames(strainsResults)
 
+	if marshallErr == nil {
+		c.writeBehind.putStrains(strainsResults)
+	}
+
 	return strainsResults, marshallErr
 }
 
@@ -232,10 +423,19 @@ type SearchStrainsByNameResults []SearchStrainsByNameResult
 // SearchStrainsByName returns a SearchStrainsByNameResults of all strains matching
 // the name passed in.
 func (c *DefaultClient) SearchStrainsByName(name string) (SearchStrainsByNameResults, error) {
+	return c.SearchStrainsByNameContext(context.Background(), name)
+}
+
+// SearchStrainsByNameContext is the context-aware version of SearchStrainsByName.
+func (c *DefaultClient) SearchStrainsByNameContext(ctx context.Context, name string) (SearchStrainsByNameResults, error) {
 	strainsResults := make(SearchStrainsByNameResults, 0)
 
-	searchURL := strainSearchBasePath + "/name/" + name
-	strainsResultsJSONBytes, err := c.simpleHTTPGet(searchURL)
+	if c.aliases != nil {
+		name = c.aliases.Resolve(name)
+	}
+
+	searchURL := strainSearchBasePath + "/name/" + url.PathEscape(name)
+	strainsResultsJSONBytes, err := c.simpleHTTPGetContext(ctx, searchURL)
 
 	if err != nil {
 		return strainsResults, err
@@ -261,10 +461,15 @@ type SearchStrainsByRaceResults []SearchStrainsByRaceResult
 // SearchStrainsByRace gets a SearchStrainsByRaceResult of all strains matching
 // the Race passed in.
 func (c *DefaultClient) SearchStrainsByRace(race Race) (SearchStrainsByRaceResults, error) {
+	return c.SearchStrainsByRaceContext(context.Background(), race)
+}
+
+// SearchStrainsByRaceContext is the context-aware version of SearchStrainsByRace.
+func (c *DefaultClient) SearchStrainsByRaceContext(ctx context.Context, race Race) (SearchStrainsByRaceResults, error) {
 	strainsResults := make(SearchStrainsByRaceResults, 0)
 
 	searchURL := strainSearchBasePath + "/race/" + url.PathEscape(string(race))
-	strainsResultsJSONBytes, err := c.simpleHTTPGet(searchURL)
+	strainsResultsJSONBytes, err := c.simpleHTTPGetContext(ctx, searchURL)
 
 	if err != nil {
 		return strainsResults, err
@@ -291,10 +496,15 @@ type SearchStrainsByEffectNameResults []SearchStrainsByEffectNameResult
 // SearchStrainsByEffectName returns a SearchStrainsByEffectNameResults of all strains
 // with an effect that matches the Effect passed in.
 func (c *DefaultClient) SearchStrainsByEffectName(effectName string) (SearchStrainsByEffectNameResults, error) {
+	return c.SearchStrainsByEffectNameContext(context.Background(), effectName)
+}
+
+// SearchStrainsByEffectNameContext is the context-aware version of SearchStrainsByEffectName.
+func (c *DefaultClient) SearchStrainsByEffectNameContext(ctx context.Context, effectName string) (SearchStrainsByEffectNameResults, error) {
 	strainsResults := make(SearchStrainsByEffectNameResults, 0)
 
 	searchURL := strainSearchBasePath + "/effect/" + url.PathEscape(string(effectName))
-	strainsResultsJSONBytes, err := c.simpleHTTPGet(searchURL)
+	strainsResultsJSONBytes, err := c.simpleHTTPGetContext(ctx, searchURL)
 
 	if err != nil {
 		return strainsResults, err
@@ -321,10 +531,15 @@ type SearchStrainsByFlavorResults []SearchStrainsByFlavorResult
 // SearchStrainsByFlavor returns a SearchStrainsByFlavorResults of all strains
 // with a flavor that matches the Flavor passed in.
 func (c *DefaultClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFlavorResults, error) {
+	return c.SearchStrainsByFlavorContext(context.Background(), flavor)
+}
+
+// SearchStrainsByFlavorContext is the context-aware version of SearchStrainsByFlavor.
+func (c *DefaultClient) SearchStrainsByFlavorContext(ctx context.Context, flavor Flavor) (SearchStrainsByFlavorResults, error) {
 	strainsResults := make(SearchStrainsByFlavorResults, 0)
 
 	searchURL := strainSearchBasePath + "/flavor/" + url.PathEscape(string(flavor))
-	strainsResultsJSONBytes, err := c.simpleHTTPGet(searchURL)
+	strainsResultsJSONBytes, err := c.simpleHTTPGetContext(ctx, searchURL)
 
 	if err != nil {
 		return strainsResults, err
@@ -337,21 +552,26 @@ func (c *DefaultClient) SearchStrainsByFlavor(flavor Flavor) (SearchStrainsByFla
 
 const strainDataBasePath string = strainsBasePath + "/data"
 
-func (c *DefaultClient) getStrainDataByID(dataElementName string, id int) ([]byte, error) {
+func (c *DefaultClient) getStrainDataByID(ctx context.Context, dataElementName string, id int) ([]byte, error) {
 	url := fmt.Sprintf("%s/%s/%d", strainDataBasePath, dataElementName, id)
 
-	return c.simpleHTTPGet(url)
+	return c.simpleHTTPGetContext(ctx, url)
 }
 
 // GetStrainDescriptionByStrainID retrieves the Description field for the
 // Strain with the ID passed in.
 func (c *DefaultClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	return c.GetStrainDescriptionByStrainIDContext(context.Background(), id)
+}
+
+// GetStrainDescriptionByStrainIDContext is the context-aware version of GetStrainDescriptionByStrainID.
+func (c *DefaultClient) GetStrainDescriptionByStrainIDContext(ctx context.Context, id int) (string, error) {
 
 	description := ""
-	descriptionResultBytes, err := c.getStrainDataByID("desc", id)
+	descriptionResultBytes, err := c.getStrainDataByID(ctx, "desc", id)
 
 	if err != nil {
-		return "", fmt.Errorf("Problem getting the description for strain with ID %d: %s", id, err)
+		return "", fmt.Errorf("Problem getting the description for strain with ID %d: %w", id, err)
 	}
 
 	result := make(map[string]string)
@@ -365,25 +585,37 @@ func (c *DefaultClient) GetStrainDescriptionByStrainID(id int) (string, error) {
 	description = result["desc"]
 
 	if description == "" {
-		return "", fmt.Errorf("Unable to find description in result")
+		return "", fmt.Errorf("Unable to find description in result: %w", ErrNotFound)
 	}
 
+	c.writeBehind.updateStrain(id, func(strain *Strain) { strain.Description = description })
+
 	return description, nil
 }
 
 // GetStrainFlavorsByStrainID returns a slice of Flavors for
 // the Strain of the id passed in.
 func (c *DefaultClient) GetStrainFlavorsByStrainID(id int) ([]Flavor, error) {
+	return c.GetStrainFlavorsByStrainIDContext(context.Background(), id)
+}
+
+// GetStrainFlavorsByStrainIDContext is the context-aware version of GetStrainFlavorsByStrainID.
+func (c *DefaultClient) GetStrainFlavorsByStrainIDContext(ctx context.Context, id int) ([]Flavor, error) {
 	flavors := make([]Flavor, 0)
 
-	flavorsResultBytes, err := c.getStrainDataByID("flavors", id)
+	flavorsResultBytes, err := c.getStrainDataByID(ctx, "flavors", id)
 	if err != nil {
-		return flavors, fmt.Errorf("Problem getting flavors for stain with ID %d: %s", id, err)
+		return flavors, fmt.Errorf("Problem getting flavors for stain with ID %d: %w", id, err)
 	}
 
 	marshallErr := json.Unmarshal(flavorsResultBytes, &flavors)
 	if marshallErr != nil {
-		return flavors, fmt.Errorf("Problem parsing flavors response for string with ID %d: %s\nBytes: %v", id, err, flavorsResultBytes)
+		return flavors, fmt.Errorf("Problem parsing flavors response for string with ID %d: %w\nBytes: %v", id, marshallErr, flavorsResultBytes)
+	}
+
+	if len(flavors) > 0 {
+		stored := append(make([]Flavor, 0), flavors...)
+		c.writeBehind.updateStrain(id, func(strain *Strain) { strain.Flavors = stored })
 	}
 
 	return flavors, nil
@@ -396,16 +628,26 @@ type EffectsByEffectType map[EffectType][]Effect
 // Use EffectTypePositive, EffectTypeNegative, and EffectTypeMedical for the keys
 // and the values are a slice of Effect items.
 func (c *DefaultClient) GetStrainEffectsByStrainID(id int) (EffectsByEffectType, error) {
+	return c.GetStrainEffectsByStrainIDContext(context.Background(), id)
+}
+
+// GetStrainEffectsByStrainIDContext is the context-aware version of GetStrainEffectsByStrainID.
+func (c *DefaultClient) GetStrainEffectsByStrainIDContext(ctx context.Context, id int) (EffectsByEffectType, error) {
 	effects := make(EffectsByEffectType)
 
-	effectsResultBytes, err := c.getStrainDataByID("effects", id)
+	effectsResultBytes, err := c.getStrainDataByID(ctx, "effects", id)
 	if err != nil {
-		return effects, fmt.Errorf("Problem retrieving effects for Strain with ID %d: %s", id, err)
+		return effects, fmt.Errorf("Problem retrieving effects for Strain with ID %d: %w", id, err)
 	}
 
 	marshallErr := json.Unmarshal(effectsResultBytes, &effects)
 	if marshallErr != nil {
-		return effects, fmt.Errorf("Problem parsing effects for Strain with ID %d: %s", id, marshallErr)
+		return effects, fmt.Errorf("Problem parsing effects for Strain with ID %d: %w", id, marshallErr)
+	}
+
+	if len(effects) > 0 {
+		stored := effectNamesByType(effects)
+		c.writeBehind.updateStrain(id, func(strain *Strain) { strain.Effects = stored })
 	}
 
 	return effects, nil
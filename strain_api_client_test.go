@@ -1,6 +1,7 @@
 package strainapiclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -337,3 +338,46 @@ func mockHandleResourceReqeustFunc(path string) ([]byte, error) {
 	// returns the path as an error as well as the byte array
 	return []byte(path), errors.New(path)
 }
+
+type testContextKey string
+
+func TestContextClientPropagatesContext(t *testing.T) {
+	defaultClient := NewDefaultClient("test-key")
+	var client ContextClient = defaultClient
+
+	key := testContextKey("request")
+	ctx := context.WithValue(context.Background(), key, "expected")
+
+	var actualValue interface{}
+	defaultClient.SetHandleResourceRequestContextFunc(func(ctx context.Context, path string) ([]byte, error) {
+		actualValue = ctx.Value(key)
+		return []byte("[\"Earthy\"]"), nil
+	})
+
+	flavors, err := client.ListAllFlavorsContext(ctx)
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	if actualValue != "expected" || len(flavors) != 1 {
+		t.Errorf("Expected the context to reach the handler and one flavor; got value %v and flavors %v", actualValue, flavors)
+	}
+}
+
+func TestContextClientCancelledWithHandleResourceRequestFunc(t *testing.T) {
+	client := NewDefaultClient("test-key")
+
+	called := false
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		called = true
+		return []byte("[]"), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.ListAllEffectsContext(ctx)
+	if !errors.Is(err, context.Canceled) || called {
+		t.Errorf("Expected context.Canceled without calling the handler; got error %v (handler called: %t)", err, called)
+	}
+}
@@ -0,0 +1,93 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"time"
+)
+
+// StrainRevision is one change to a strain stored in a FileBackedClient:
+// when it happened, the fields that changed, and the strain as it was
+// before.  Deleted revisions record the strain being deleted (see
+// DeleteStrain) and have no Changes.
+type StrainRevision struct {
+	Time     time.Time           `json:"time"`
+	Changes  []StrainFieldChange `json:"changes,omitempty"`
+	Previous Strain              `json:"previous"`
+	Deleted  bool                `json:"deleted,omitempty"`
+}
+
+// GetStrainHistory returns the changes to the strain with id since the
+// client was created (or, for a Syncer's client, since the Syncer's first
+// sync), oldest first, for displays like "description updated on ..." and
+// audits.  Deleted strains keep their history.  An error wrapping
+// ErrNotFound is returned when the client has never had a strain with id.
+func (c *FileBackedClient) GetStrainHistory(id int) ([]StrainRevision, error) {
+	c.dataMutex.RLock()
+	defer c.dataMutex.RUnlock()
+
+	_, live := c.byID[id]
+	_, deleted := c.tombstones[id]
+	if !live && !deleted {
+		return nil, fmt.Errorf("Strain with ID %d is not in the snapshot: %w", id, ErrNotFound)
+	}
+
+	return append(make([]StrainRevision, 0), c.history[id]...), nil
+}
+
+// recordRevisions adds a revision, at revisedAt, for each of strains that
+// differs from the stored strain with its ID; the caller holds dataMutex.
+func (c *FileBackedClient) recordRevisions(revisedAt time.Time, strains ...Strain) {
+	for _, strain := range strains {
+		if strain.Name == "" {
+			continue
+		}
+
+		previous, found := c.byID[strain.ID]
+		if !found {
+			continue
+		}
+
+		if changes := diffStrainFields(previous, strain); len(changes) > 0 {
+			c.addRevision(strain.ID, StrainRevision{Time: revisedAt, Changes: changes, Previous: previous})
+		}
+	}
+}
+
+// addRevision appends revision to the history of the strain with id; the
+// caller holds dataMutex.  The revisions are copied since carryHistory
+// shares them between clients.
+func (c *FileBackedClient) addRevision(id int, revision StrainRevision) {
+	if c.history == nil {
+		c.history = make(map[int][]StrainRevision)
+	}
+
+	c.history[id] = append(append(make([]StrainRevision, 0), c.history[id]...), revision)
+}
+
+// carryHistory copies the history and Tombstones of previous and records
+// the changes in diff, so both survive a sync replacing the whole dataset.
+func (c *FileBackedClient) carryHistory(previous *FileBackedClient, diff SnapshotDiff) {
+	previous.dataMutex.RLock()
+	defer previous.dataMutex.RUnlock()
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	c.history = make(map[int][]StrainRevision)
+	for id, revisions := range previous.history {
+		c.history[id] = revisions
+	}
+
+	c.tombstones = make(map[int]Tombstone)
+	for id, tombstone := range previous.tombstones {
+		if _, found := c.byID[id]; !found {
+			c.tombstones[id] = tombstone
+		}
+	}
+
+	now := time.Now()
+	for _, change := range diff.Changed {
+		c.addRevision(change.ID, StrainRevision{Time: now, Changes: change.Changes, Previous: previous.byID[change.ID]})
+	}
+
+	c.tombstone(now, diff.Removed...)
+}
@@ -0,0 +1,61 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetStrainHistory(t *testing.T) {
+	client := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{
+		"Afpak": {Name: "Afpak", ID: 1, Race: RaceHybrid, Description: "Old"},
+	}})
+
+	if history, err := client.GetStrainHistory(1); err != nil || len(history) != 0 {
+		t.Errorf("Expected no history yet but got %v (error: %v)", history, err)
+	}
+
+	client.PutStrain(Strain{Name: "Afpak", ID: 1, Race: RaceHybrid, Description: "New"})
+	client.PutStrain(Strain{Name: "Afpak", ID: 1, Race: RaceHybrid, Description: "New"})
+	client.Evict(100)
+	client.DeleteStrain(1)
+
+	history, err := client.GetStrainHistory(1)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if len(history) != 2 {
+		t.Fatalf("Expected a change and a deletion but got %v", history)
+	}
+
+	if history[0].Previous.Description != "Old" || len(history[0].Changes) != 1 || history[0].Changes[0].New != "New" {
+		t.Errorf("Expected the description change from Old to New but got %+v", history[0])
+	}
+
+	if !history[1].Deleted || history[1].Time.Before(history[0].Time) {
+		t.Errorf("Expected the deletion last but got %+v", history[1])
+	}
+
+	if _, err := client.GetStrainHistory(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown strain but got %v", err)
+	}
+}
+
+func TestSyncerRecordsStrainHistory(t *testing.T) {
+	upstream := NewSnapshotClient(Snapshot{Strains: ListAllStrainsResult{
+		"Afpak": {Name: "Afpak", ID: 1, Race: RaceHybrid, Flavors: []Flavor{"Earthy"}},
+	}})
+	syncer := NewSyncer(upstream, time.Hour)
+	syncer.Sync()
+
+	upstream.PutStrain(Strain{Name: "Afpak", ID: 1, Race: RaceHybrid, Flavors: []Flavor{"Earthy", "Pine"}})
+	syncer.Sync()
+	upstream.PutStrain(Strain{Name: "Afpak", ID: 1, Race: RaceIndica, Flavors: []Flavor{"Earthy", "Pine"}})
+	syncer.Sync()
+
+	history, _ := syncer.Client().GetStrainHistory(1)
+	if len(history) != 2 || history[0].Changes[0].Field != StrainFieldFlavors || history[1].Changes[0].Field != StrainFieldRace {
+		t.Errorf("Expected the flavors and then race changes but got %+v", history)
+	}
+}
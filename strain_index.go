@@ -0,0 +1,166 @@
+package strainapiclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stemSuffixes are stripped from terms, longest first, so "relaxing",
+// "relaxed", and "relaxes" all index as "relax".
+var stemSuffixes = []string{"ingly", "edly", "ing", "ies", "ed", "es", "ly", "s"}
+
+// StrainIndexResult is one strain found by StrainIndex.Search and how well
+// it matched.
+type StrainIndexResult struct {
+	Strain Strain  `json:"strain"`
+	Score  float64 `json:"score"`
+}
+
+// StrainIndex is an in-memory full-text index over the names,
+// descriptions, flavors, and effects of a set of strains, for local
+// queries like "citrus relaxing" that the API can't answer.  Terms are
+// lowercased and stemmed.  A StrainIndex is read-only once built, so it
+// is safe for concurrent use.
+type StrainIndex struct {
+	strains  map[int]Strain
+	postings map[string]map[int]float64
+	ranking  RankingConfig
+}
+
+// NewStrainIndex builds a StrainIndex over strains.  Descriptions are only
+// indexed when set, so index a hydrated dataset (see ExportJSONLines) to
+// search them.  Matches are ranked with DefaultRankingConfig.
+func NewStrainIndex(strains ListAllStrainsResult) *StrainIndex {
+	return NewStrainIndexWithRanking(strains, DefaultRankingConfig())
+}
+
+// NewStrainIndexWithRanking builds a StrainIndex over strains that ranks
+// matches with ranking's weights.
+func NewStrainIndexWithRanking(strains ListAllStrainsResult, ranking RankingConfig) *StrainIndex {
+	index := &StrainIndex{strains: make(map[int]Strain), postings: make(map[string]map[int]float64), ranking: ranking}
+
+	for name, strain := range strains {
+		if strain.Name == "" {
+			strain.Name = name
+		}
+		index.strains[strain.ID] = strain
+
+		index.add(strain.ID, strain.Name, ranking.NameWeight)
+		index.add(strain.ID, strain.Description, ranking.DescriptionWeight)
+		for _, flavor := range strain.Flavors {
+			index.add(strain.ID, string(flavor), ranking.FlavorWeight)
+		}
+		for _, names := range strain.Effects {
+			for _, effect := range names {
+				index.add(strain.ID, effect, ranking.EffectWeight)
+			}
+		}
+	}
+
+	return index
+}
+
+// LoadStrainIndex builds a StrainIndex over every strain listed by client.
+// The listing doesn't include descriptions, so each strain is hydrated
+// first, which makes one description request per strain.
+func LoadStrainIndex(client Client) (*StrainIndex, error) {
+	strains, err := client.ListAllStrains()
+	if err != nil {
+		return nil, fmt.Errorf("Problem listing strains to index: %w", err)
+	}
+
+	strains, err = HydrateStrains(context.Background(), client, strains, DefaultBatchConcurrency)
+	if err != nil {
+		return nil, fmt.Errorf("Problem fetching strain descriptions to index: %w", err)
+	}
+
+	return NewStrainIndex(strains), nil
+}
+
+// Len returns the number of strains in the index.
+func (i *StrainIndex) Len() int {
+	return len(i.strains)
+}
+
+// Search returns the strains matching any term of query, best match first.
+// Each matching term adds the weight of the field it matched (by default
+// higher for names than flavors and effects, and for those than
+// descriptions) times how rare the term is across the index; ties are in
+// ID order.
+func (i *StrainIndex) Search(query string) []StrainIndexResult {
+	scores := make(map[int]float64)
+
+	for _, term := range indexTerms(query) {
+		postings := i.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		rarity := math.Log(1 + float64(len(i.strains))/float64(len(postings)))
+		for id, weight := range postings {
+			scores[id] += weight * rarity
+		}
+	}
+
+	results := make([]StrainIndexResult, 0)
+	for id, score := range scores {
+		results = append(results, StrainIndexResult{Strain: i.strains[id], Score: score})
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Strain.ID < results[b].Strain.ID
+	})
+
+	return results
+}
+
+// add indexes each term of text for the strain with id, unless the field
+// has no weight.
+func (i *StrainIndex) add(id int, text string, weight float64) {
+	if weight <= 0 {
+		return
+	}
+
+	for _, term := range indexTerms(text) {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[int]float64)
+		}
+		i.postings[term][id] += weight
+	}
+}
+
+// indexTerms splits text into lowercased, stemmed terms.
+func indexTerms(text string) []string {
+	terms := make([]string, 0)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		terms = append(terms, stem(word))
+	}
+
+	return terms
+}
+
+// stem strips a common English suffix from word, keeping at least three
+// letters, and turns a trailing "ies" into "y".
+func stem(word string) string {
+	for _, suffix := range stemSuffixes {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			if suffix == "ies" {
+				return strings.TrimSuffix(word, suffix) + "y"
+			}
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+
+	return word
+}
@@ -0,0 +1,89 @@
+package strainapiclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestStrainIndex() *StrainIndex {
+	return NewStrainIndex(ListAllStrainsResult{
+		"Lemon Haze": {ID: 1, Description: "Bright citrus notes.", Flavors: []Flavor{"Lemon"},
+			Effects: map[EffectType][]string{EffectTypePositive: {"Energetic"}}},
+		"Citrus Kush": {ID: 2, Description: "A relaxing indica.", Flavors: []Flavor{"Earthy"},
+			Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed"}}},
+		"Blue Dream": {ID: 3, Description: "Sweet berry flavor.", Flavors: []Flavor{"Berry"},
+			Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}},
+	})
+}
+
+func TestStrainIndexSearch(t *testing.T) {
+	index := newTestStrainIndex()
+
+	results := index.Search("citrus relaxing")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results but got %v", results)
+	}
+
+	// Citrus Kush matches citrus in its name and relax in its effects and description
+	if results[0].Strain.Name != "Citrus Kush" || results[1].Strain.Name != "Lemon Haze" {
+		t.Errorf("Expected Citrus Kush then Lemon Haze but got %s then %s", results[0].Strain.Name, results[1].Strain.Name)
+	}
+
+	if results[0].Score <= results[1].Score {
+		t.Errorf("Expected descending scores but got %f then %f", results[0].Score, results[1].Score)
+	}
+}
+
+func TestStrainIndexSearchNoMatches(t *testing.T) {
+	index := newTestStrainIndex()
+
+	if results := index.Search("diesel"); len(results) != 0 {
+		t.Errorf("Expected no results but got %v", results)
+	}
+
+	if index.Len() != 3 {
+		t.Errorf("Expected 3 strains but got %d", index.Len())
+	}
+}
+
+func TestStem(t *testing.T) {
+	for word, expected := range map[string]string{"relaxing": "relax", "relaxed": "relax", "berries": "berry", "kush": "kush", "is": "is"} {
+		if actual := stem(word); actual != expected {
+			t.Errorf("Expected %s to stem to %s but got %s", word, expected, actual)
+		}
+	}
+}
+
+func TestLoadStrainIndexHydratesDescriptions(t *testing.T) {
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"], \"effects\": {\"positive\": [\"Relaxed\"]}}}"), nil
+		case strings.Contains(path, "/strains/data/desc/1"):
+			return []byte("{\"desc\": \"A landrace hybrid\"}"), nil
+		}
+		return []byte("{}"), nil
+	})
+
+	index, err := LoadStrainIndex(client)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if results := index.Search("landrace"); len(results) != 1 || results[0].Strain.Name != "Afpak" {
+		t.Errorf("Expected to find Afpak by its description but got %v", results)
+	}
+}
+
+func TestLoadStrainIndexError(t *testing.T) {
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 500}
+	})
+
+	if _, err := LoadStrainIndex(client); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError but got %v", err)
+	}
+}
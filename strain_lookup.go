@@ -0,0 +1,162 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GetStrainByName returns the strain named name, ignoring case, with its
+// description, race, flavors, and effects.  The API's name search matches
+// any part of a name, so its results are filtered for an exact match; if
+// several strains match ignoring case, the one matching case exactly (or
+// else the first) is returned.  An error wrapping ErrNotFound is returned
+// when no strain has that name.
+func GetStrainByName(client Client, name string) (Strain, error) {
+	results, err := client.SearchStrainsByName(name)
+	if err != nil {
+		return Strain{}, fmt.Errorf("Problem searching for strain %s: %w", name, err)
+	}
+
+	var match *SearchStrainsByNameResult
+	for i, result := range results {
+		if result.Name == name {
+			match = &results[i]
+			break
+		}
+		if match == nil && strings.EqualFold(result.Name, name) {
+			match = &results[i]
+		}
+	}
+
+	if match == nil {
+		return Strain{}, fmt.Errorf("No strain is named %s: %w", name, ErrNotFound)
+	}
+
+	strain := Strain{Name: match.Name, ID: match.ID, Description: match.Description, Race: match.Race}
+	if err := hydrateStrain(context.Background(), client, &strain); err != nil {
+		return Strain{}, err
+	}
+
+	return strain, nil
+}
+
+// GetStrainByID returns the strain with id, populated with its
+// description, flavors, and effects from the three per-strain endpoints,
+// which are called concurrently.  The endpoints don't return the strain's
+// name or race, so those are left empty; use ListAllStrains or
+// GetStrainByName when they are needed.  An error wrapping ErrNotFound is
+// returned when none of the endpoints have data for id.
+func GetStrainByID(client Client, id int) (Strain, error) {
+	strain := Strain{ID: id}
+	var descriptionErr, flavorsErr, effectsErr error
+	var effects EffectsByEffectType
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		strain.Description, descriptionErr = client.GetStrainDescriptionByStrainID(id)
+	}()
+	go func() {
+		defer wg.Done()
+		strain.Flavors, flavorsErr = client.GetStrainFlavorsByStrainID(id)
+	}()
+	go func() {
+		defer wg.Done()
+		effects, effectsErr = client.GetStrainEffectsByStrainID(id)
+	}()
+	wg.Wait()
+
+	for _, err := range []error{descriptionErr, flavorsErr, effectsErr} {
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return Strain{}, fmt.Errorf("Problem getting strain with ID %d: %w", id, err)
+		}
+	}
+
+	strain.Effects = effectNamesByType(effects)
+
+	if strain.Description == "" && len(strain.Flavors) == 0 && len(strain.Effects) == 0 {
+		return Strain{}, fmt.Errorf("No strain has ID %d: %w", id, ErrNotFound)
+	}
+
+	if strain.Flavors == nil {
+		strain.Flavors = make([]Flavor, 0)
+	}
+
+	return strain, nil
+}
+
+// DefaultBatchConcurrency is the number of strains GetStrainsByIDs fetches
+// at once unless WithBatchConcurrency is given.
+const DefaultBatchConcurrency int = 8
+
+type batchConfig struct {
+	concurrency int
+}
+
+// BatchOption configures GetStrainsByIDs.
+type BatchOption func(*batchConfig)
+
+// WithBatchConcurrency sets the number of strains fetched at once.  Each
+// strain makes three concurrent calls, so the number of calls in flight is
+// three times concurrency.  Values below 1 are treated as 1.
+func WithBatchConcurrency(concurrency int) BatchOption {
+	return func(config *batchConfig) {
+		config.concurrency = concurrency
+	}
+}
+
+// GetStrainsByIDs fetches each strain in ids with GetStrainByID, through a
+// pool of workers, for jobs needing hundreds of strains.  It returns the
+// strains fetched by ID and the error for each ID that failed (wrapping
+// ErrNotFound for IDs no strain has); an ID is in exactly one of the two.
+// Duplicate IDs are fetched once.
+func GetStrainsByIDs(client Client, ids []int, opts ...BatchOption) (map[int]Strain, map[int]error) {
+	config := batchConfig{concurrency: DefaultBatchConcurrency}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+
+	strains := make(map[int]Strain)
+	errs := make(map[int]error)
+	var mutex sync.Mutex
+
+	queue := make(chan int)
+	var workers sync.WaitGroup
+	for worker := 0; worker < config.concurrency; worker++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for id := range queue {
+				strain, err := GetStrainByID(client, id)
+
+				mutex.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					strains[id] = strain
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	queued := make(map[int]bool)
+	for _, id := range ids {
+		if !queued[id] {
+			queued[id] = true
+			queue <- id
+		}
+	}
+	close(queue)
+	workers.Wait()
+
+	return strains, errs
+}
@@ -0,0 +1,92 @@
+package strainapiclient
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newLookupTestClient() *DefaultClient {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/search/name/"):
+			return []byte("[{\"id\": 1, \"name\": \"Blue Dream\", \"race\": \"hybrid\", \"desc\": \"Sweet\"}," +
+				"{\"id\": 2, \"name\": \"Blue Dream Haze\", \"race\": \"sativa\", \"desc\": null}]"), nil
+		case strings.Contains(path, "/strains/data/desc/1"):
+			return []byte("{\"desc\": \"Sweet\"}"), nil
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte("{}"), nil
+		case strings.Contains(path, "/strains/data/flavors/1"):
+			return []byte("[\"Berry\"]"), nil
+		case strings.Contains(path, "/strains/data/effects/1"):
+			return []byte("{\"positive\": [\"Happy\"]}"), nil
+		case strings.Contains(path, "/strains/data/effects/"):
+			return []byte("{}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	return client
+}
+
+func TestGetStrainByName(t *testing.T) {
+	strain, err := GetStrainByName(newLookupTestClient(), "blue dream")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	expected := Strain{Name: "Blue Dream", ID: 1, Description: "Sweet", Race: RaceHybrid, Flavors: []Flavor{"Berry"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}}
+	if !reflect.DeepEqual(strain, expected) {
+		t.Errorf("Expected %v but got %v", expected, strain)
+	}
+}
+
+func TestGetStrainByNameNotFound(t *testing.T) {
+	if _, err := GetStrainByName(newLookupTestClient(), "Blue"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a partial name but got %v", err)
+	}
+}
+
+func TestGetStrainByID(t *testing.T) {
+	strain, err := GetStrainByID(newLookupTestClient(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	expected := Strain{ID: 1, Description: "Sweet", Flavors: []Flavor{"Berry"}, Effects: map[EffectType][]string{EffectTypePositive: {"Happy"}}}
+	if !reflect.DeepEqual(strain, expected) {
+		t.Errorf("Expected %v but got %v", expected, strain)
+	}
+}
+
+func TestGetStrainByIDNotFound(t *testing.T) {
+	if _, err := GetStrainByID(newLookupTestClient(), 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
+
+func TestGetStrainByIDError(t *testing.T) {
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 500}
+	})
+
+	if _, err := GetStrainByID(client, 1); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError but got %v", err)
+	}
+}
+
+func TestGetStrainsByIDs(t *testing.T) {
+	strains, errs := GetStrainsByIDs(newLookupTestClient(), []int{1, 2, 1}, WithBatchConcurrency(2))
+
+	if len(strains) != 1 || strains[1].Description != "Sweet" {
+		t.Errorf("Expected strain 1 to be fetched but got %v", strains)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[2], ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for strain 2 but got %v", errs)
+	}
+}
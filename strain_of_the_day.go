@@ -0,0 +1,64 @@
+package strainapiclient
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// StrainFilter reports whether a Strain should be included.
+type StrainFilter func(strain Strain) bool
+
+// FilterByRace returns a StrainFilter that only includes strains of race.
+func FilterByRace(race Race) StrainFilter {
+	return func(strain Strain) bool {
+		return strain.Race == race
+	}
+}
+
+// SelectStrainOfTheDay deterministically selects one strain for the calendar
+// day of date (in date's location) from the strains that pass every filter.
+// Every caller with the same dataset picks the same strain for the same day,
+// so multiple instances agree without coordinating.  ErrNotFound is returned
+// when no strain passes the filters.
+func SelectStrainOfTheDay(strains ListAllStrainsResult, date time.Time, filters ...StrainFilter) (Strain, error) {
+	names := make([]string, 0)
+
+	for name, strain := range strains {
+		included := true
+		for _, filter := range filters {
+			if !filter(strain) {
+				included = false
+				break
+			}
+		}
+
+		if included {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return Strain{}, fmt.Errorf("No strain of the day for %s: %w", date.Format("2006-01-02"), ErrNotFound)
+	}
+
+	sort.Strings(names)
+
+	hash := fnv.New64a()
+	hash.Write([]byte(date.Format("2006-01-02")))
+
+	return strains[names[hash.Sum64()%uint64(len(names))]], nil
+}
+
+// StrainOfTheDay lists all strains and selects the strain of the day for
+// date using SelectStrainOfTheDay.  Since ListAllStrains is expensive, use
+// SelectStrainOfTheDay directly if you already have the strain list.
+func (c *DefaultClient) StrainOfTheDay(date time.Time, filters ...StrainFilter) (Strain, error) {
+	strains, err := c.ListAllStrains()
+	if err != nil {
+		return Strain{}, err
+	}
+
+	return SelectStrainOfTheDay(strains, date, filters...)
+}
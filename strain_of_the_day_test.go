@@ -0,0 +1,47 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSelectStrainOfTheDayIsStablePerDay(t *testing.T) {
+	strains := NewFakeDatasetGenerator(1).Strains(100)
+	morning := time.Date(2020, 7, 4, 8, 0, 0, 0, time.UTC)
+	evening := time.Date(2020, 7, 4, 22, 0, 0, 0, time.UTC)
+
+	first, err := SelectStrainOfTheDay(strains, morning)
+	if err != nil {
+		t.Errorf("Expected no error but got: %s", err)
+	}
+
+	second, _ := SelectStrainOfTheDay(strains, evening)
+	if first.Name != second.Name {
+		t.Errorf("Expected the same strain all day but got '%s' and '%s'", first.Name, second.Name)
+	}
+
+	differentDays := false
+	for day := 1; day <= 10; day++ {
+		other, _ := SelectStrainOfTheDay(strains, morning.AddDate(0, 0, day))
+		differentDays = differentDays || other.Name != first.Name
+	}
+
+	if !differentDays {
+		t.Error("Expected the strain of the day to change across days")
+	}
+}
+
+func TestSelectStrainOfTheDayFilters(t *testing.T) {
+	strains := NewFakeDatasetGenerator(1).Strains(100)
+
+	strain, err := SelectStrainOfTheDay(strains, time.Now(), FilterByRace(RaceSativa))
+	if err != nil || strain.Race != RaceSativa {
+		t.Errorf("Expected a sativa strain but got %v (error: %v)", strain, err)
+	}
+
+	noneMatch := func(strain Strain) bool { return false }
+	if _, err := SelectStrainOfTheDay(strains, time.Now(), noneMatch); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
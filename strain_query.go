@@ -0,0 +1,307 @@
+package strainapiclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// excludedEffect is an effect strains must not have in a StrainQuery.
+type excludedEffect struct {
+	effectType EffectType
+	name       string
+}
+
+// StrainQuery finds the strains matching a combination of name, race,
+// effects, and flavors, which the API can only search one at a time.  Build one
+// with NewStrainQuery (or DefaultClient.Query), add filters, and call Run:
+//
+//	strains, err := client.Query().Race(RaceHybrid).WithEffect("Relaxed").
+//		WithFlavor("Citrus").Exclude(EffectTypeNegative, "Paranoid").Run(ctx)
+//
+// Every filter must match.  Filters can be added in any order; Run decides
+// which searches to make.  ParseStrainQuery builds one from text.
+type StrainQuery struct {
+	client     Client
+	name       string
+	race       Race
+	effects    []string
+	flavors    []Flavor
+	excluded   []excludedEffect
+	minQuality float64
+}
+
+// NewStrainQuery creates a new StrainQuery with no filters, searching
+// through client.
+func NewStrainQuery(client Client) *StrainQuery {
+	return &StrainQuery{client: client, effects: make([]string, 0), flavors: make([]Flavor, 0), excluded: make([]excludedEffect, 0)}
+}
+
+// Query creates a new StrainQuery searching through the client.
+func (c *DefaultClient) Query() *StrainQuery {
+	return NewStrainQuery(c)
+}
+
+// Name limits the query to strains whose name contains name, ignoring
+// case, like SearchStrainsByName, replacing any name set before.  A name
+// ending in * matches names starting with the rest instead.
+func (q *StrainQuery) Name(name string) *StrainQuery {
+	q.name = name
+	return q
+}
+
+// Race limits the query to strains of race, replacing any race set before.
+func (q *StrainQuery) Race(race Race) *StrainQuery {
+	q.race = race
+	return q
+}
+
+// WithEffect limits the query to strains with the effect named effectName.
+func (q *StrainQuery) WithEffect(effectName string) *StrainQuery {
+	q.effects = append(q.effects, effectName)
+	return q
+}
+
+// WithFlavor limits the query to strains with flavor.
+func (q *StrainQuery) WithFlavor(flavor Flavor) *StrainQuery {
+	q.flavors = append(q.flavors, flavor)
+	return q
+}
+
+// Exclude removes strains with the effect named effectName from the query.
+// The API's effect search doesn't report effect types, so strains are
+// matched by name; effect names are unique across types, so effectType
+// only documents which kind of effect is being excluded.
+func (q *StrainQuery) Exclude(effectType EffectType, effectName string) *StrainQuery {
+	q.excluded = append(q.excluded, excludedEffect{effectType: effectType, name: effectName})
+	return q
+}
+
+// MinQuality limits the query to strains with a QualityScore of at least
+// minScore, replacing any minimum set before.  Scoring needs each matching
+// strain's description, flavors, and effects, so Run hydrates them.
+func (q *StrainQuery) MinQuality(minScore float64) *StrainQuery {
+	q.minQuality = minScore
+	return q
+}
+
+// Run makes the searches needed to answer the query and returns the
+// matching strains in ID order, with their name, ID, and race (use
+// HydrateStrain for the rest).  It searches once for the name and once
+// per effect, flavor, and excluded effect and intersects the results; the race is checked against
+// those results and only searched when it is the only filter.  Searches
+// stop as soon as nothing can match.  A query with no filters other than
+// exclusions starts from ListAllStrains.  With a MinQuality, the matching
+// strains are then hydrated (see HydrateStrains) and returned with all their
+// data.
+//
+// If the client is also a ContextClient, ctx is passed to its calls;
+// otherwise ctx is checked between calls.
+func (q *StrainQuery) Run(ctx context.Context) ([]Strain, error) {
+	searches := make([]func() ([]Strain, error), 0)
+	if q.name != "" {
+		searches = append(searches, func() ([]Strain, error) { return q.searchName(ctx, q.name) })
+	}
+	for _, effectName := range q.effects {
+		effectName := effectName
+		searches = append(searches, func() ([]Strain, error) { return q.searchEffect(ctx, effectName) })
+	}
+	for _, flavor := range q.flavors {
+		flavor := flavor
+		searches = append(searches, func() ([]Strain, error) { return q.searchFlavor(ctx, flavor) })
+	}
+	if len(searches) == 0 && q.race != "" {
+		searches = append(searches, func() ([]Strain, error) { return q.searchRace(ctx, q.race) })
+	}
+	if len(searches) == 0 {
+		searches = append(searches, func() ([]Strain, error) { return q.listAll(ctx) })
+	}
+
+	var candidates map[int]Strain
+	for _, search := range searches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		strains, err := search()
+		if err != nil {
+			return nil, err
+		}
+
+		matches := make(map[int]Strain)
+		for _, strain := range strains {
+			if q.race != "" && strain.Race != q.race {
+				continue
+			}
+			if _, found := candidates[strain.ID]; candidates == nil || found {
+				matches[strain.ID] = strain
+			}
+		}
+
+		candidates = matches
+		if len(candidates) == 0 {
+			return make([]Strain, 0), nil
+		}
+	}
+
+	for _, excluded := range q.excluded {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		strains, err := q.searchEffect(ctx, excluded.name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, strain := range strains {
+			delete(candidates, strain.ID)
+		}
+	}
+
+	if q.minQuality > 0 && len(candidates) > 0 {
+		var err error
+		if candidates, err = q.filterQuality(ctx, candidates); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]Strain, 0)
+	for _, strain := range candidates {
+		results = append(results, strain)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+
+	return results, nil
+}
+
+// filterQuality hydrates candidates and keeps those with a QualityScore of
+// at least the query's MinQuality.
+func (q *StrainQuery) filterQuality(ctx context.Context, candidates map[int]Strain) (map[int]Strain, error) {
+	byName := make(ListAllStrainsResult)
+	for _, strain := range candidates {
+		byName[strain.Name] = strain
+	}
+
+	hydrated, err := hydrateStrains(ctx, q.client, byName, DefaultBatchConcurrency)
+	if err != nil {
+		return nil, fmt.Errorf("Problem hydrating strains to score their quality: %w", err)
+	}
+
+	filter := FilterByMinQuality(q.minQuality)
+	matches := make(map[int]Strain)
+	for _, strain := range hydrated {
+		if filter(strain) {
+			matches[strain.ID] = strain
+		}
+	}
+
+	return matches, nil
+}
+
+func (q *StrainQuery) searchName(ctx context.Context, name string) ([]Strain, error) {
+	prefix := strings.HasSuffix(name, "*")
+	name = strings.TrimSuffix(name, "*")
+
+	var results SearchStrainsByNameResults
+	var err error
+	if contextClient, ok := q.client.(ContextClient); ok {
+		results, err = contextClient.SearchStrainsByNameContext(ctx, name)
+	} else {
+		results, err = q.client.SearchStrainsByName(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem searching for strains named %s: %w", name, err)
+	}
+
+	strains := make([]Strain, 0)
+	for _, result := range results {
+		if prefix && !strings.HasPrefix(strings.ToLower(result.Name), strings.ToLower(name)) {
+			continue
+		}
+		strains = append(strains, Strain{Name: result.Name, ID: result.ID, Race: result.Race})
+	}
+
+	return strains, nil
+}
+
+func (q *StrainQuery) searchEffect(ctx context.Context, effectName string) ([]Strain, error) {
+	var results SearchStrainsByEffectNameResults
+	var err error
+	if contextClient, ok := q.client.(ContextClient); ok {
+		results, err = contextClient.SearchStrainsByEffectNameContext(ctx, effectName)
+	} else {
+		results, err = q.client.SearchStrainsByEffectName(effectName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem searching for strains with effect %s: %w", effectName, err)
+	}
+
+	strains := make([]Strain, 0)
+	for _, result := range results {
+		strains = append(strains, Strain{Name: result.Name, ID: result.ID, Race: result.Race})
+	}
+
+	return strains, nil
+}
+
+func (q *StrainQuery) searchFlavor(ctx context.Context, flavor Flavor) ([]Strain, error) {
+	var results SearchStrainsByFlavorResults
+	var err error
+	if contextClient, ok := q.client.(ContextClient); ok {
+		results, err = contextClient.SearchStrainsByFlavorContext(ctx, flavor)
+	} else {
+		results, err = q.client.SearchStrainsByFlavor(flavor)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem searching for strains with flavor %s: %w", flavor, err)
+	}
+
+	strains := make([]Strain, 0)
+	for _, result := range results {
+		strains = append(strains, Strain{Name: result.Name, ID: result.ID, Race: result.Race})
+	}
+
+	return strains, nil
+}
+
+func (q *StrainQuery) searchRace(ctx context.Context, race Race) ([]Strain, error) {
+	var results SearchStrainsByRaceResults
+	var err error
+	if contextClient, ok := q.client.(ContextClient); ok {
+		results, err = contextClient.SearchStrainsByRaceContext(ctx, race)
+	} else {
+		results, err = q.client.SearchStrainsByRace(race)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem searching for %s strains: %w", race, err)
+	}
+
+	strains := make([]Strain, 0)
+	for _, result := range results {
+		strains = append(strains, Strain{Name: result.Name, ID: result.ID, Race: result.Race})
+	}
+
+	return strains, nil
+}
+
+func (q *StrainQuery) listAll(ctx context.Context) ([]Strain, error) {
+	var all ListAllStrainsResult
+	var err error
+	if contextClient, ok := q.client.(ContextClient); ok {
+		all, err = contextClient.ListAllStrainsContext(ctx)
+	} else {
+		all, err = q.client.ListAllStrains()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Problem listing strains to query: %w", err)
+	}
+
+	strains := make([]Strain, 0)
+	for name, strain := range all {
+		strains = append(strains, Strain{Name: name, ID: strain.ID, Race: strain.Race})
+	}
+
+	return strains, nil
+}
@@ -0,0 +1,123 @@
+package strainapiclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newQueryTestClient() (*DefaultClient, *[]string) {
+	var mutex sync.Mutex
+	calls := make([]string, 0)
+
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		mutex.Lock()
+		calls = append(calls, path[strings.Index(path, "/strains"):])
+		mutex.Unlock()
+
+		switch {
+		case strings.HasSuffix(path, "/effect/Relaxed"):
+			return []byte("[{\"id\": 1, \"name\": \"Afpak\", \"race\": \"hybrid\", \"effect\": \"Relaxed\"}," +
+				"{\"id\": 2, \"name\": \"Blue Dream\", \"race\": \"hybrid\", \"effect\": \"Relaxed\"}," +
+				"{\"id\": 3, \"name\": \"Northern Lights\", \"race\": \"indica\", \"effect\": \"Relaxed\"}," +
+				"{\"id\": 4, \"name\": \"Lemon Haze\", \"race\": \"hybrid\", \"effect\": \"Relaxed\"}]"), nil
+		case strings.HasSuffix(path, "/flavor/Citrus"):
+			return []byte("[{\"id\": 2, \"name\": \"Blue Dream\", \"race\": \"hybrid\", \"flavor\": \"Citrus\"}," +
+				"{\"id\": 3, \"name\": \"Northern Lights\", \"race\": \"indica\", \"flavor\": \"Citrus\"}," +
+				"{\"id\": 4, \"name\": \"Lemon Haze\", \"race\": \"hybrid\", \"flavor\": \"Citrus\"}]"), nil
+		case strings.HasSuffix(path, "/effect/Paranoid"):
+			return []byte("[{\"id\": 4, \"name\": \"Lemon Haze\", \"race\": \"hybrid\", \"effect\": \"Paranoid\"}]"), nil
+		case strings.HasSuffix(path, "/name/og"):
+			return []byte("[{\"id\": 5, \"name\": \"OG Kush\", \"race\": \"hybrid\", \"desc\": null}," +
+				"{\"id\": 6, \"name\": \"Blue OG\", \"race\": \"indica\", \"desc\": null}]"), nil
+		case strings.HasSuffix(path, "/race/indica"):
+			return []byte("[{\"id\": 3, \"name\": \"Northern Lights\", \"race\": \"indica\"}]"), nil
+		case strings.HasSuffix(path, "/data/desc/2"):
+			return []byte("{\"desc\": \"Sweet and balanced\"}"), nil
+		case strings.Contains(path, "/data/desc/"):
+			return []byte("{}"), nil
+		case strings.Contains(path, "/data/flavors/"):
+			return []byte("[\"Citrus\"]"), nil
+		case strings.HasSuffix(path, "/data/effects/2"):
+			return []byte("{\"positive\": [\"Relaxed\"], \"negative\": [\"Dry Mouth\"], \"medical\": [\"Stress\"]}"), nil
+		case strings.Contains(path, "/data/effects/"):
+			return []byte("{\"positive\": [\"Relaxed\"]}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	return client, &calls
+}
+
+func TestStrainQueryRun(t *testing.T) {
+	client, calls := newQueryTestClient()
+
+	strains, err := client.Query().Race(RaceHybrid).WithEffect("Relaxed").WithFlavor("Citrus").
+		Exclude(EffectTypeNegative, "Paranoid").Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if len(strains) != 1 || strains[0].Name != "Blue Dream" || strains[0].Race != RaceHybrid {
+		t.Errorf("Expected only Blue Dream but got %v", strains)
+	}
+
+	if len(*calls) != 3 {
+		t.Errorf("Expected searches for the effect, flavor, and exclusion only but got %v", *calls)
+	}
+}
+
+func TestStrainQueryMinQuality(t *testing.T) {
+	client, _ := newQueryTestClient()
+
+	strains, err := client.Query().Race(RaceHybrid).WithEffect("Relaxed").Exclude(EffectTypeNegative, "Paranoid").
+		MinQuality(0.75).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %s", err)
+	}
+
+	if len(strains) != 1 || strains[0].Name != "Blue Dream" || strains[0].Description != "Sweet and balanced" {
+		t.Errorf("Expected only a hydrated Blue Dream but got %v", strains)
+	}
+}
+
+func TestStrainQueryRaceOnly(t *testing.T) {
+	client, calls := newQueryTestClient()
+
+	strains, err := client.Query().Race(RaceIndica).Run(context.Background())
+	if err != nil || len(strains) != 1 || strains[0].ID != 3 {
+		t.Errorf("Expected Northern Lights but got %v (error: %v)", strains, err)
+	}
+
+	if len(*calls) != 1 || !strings.HasSuffix((*calls)[0], "/race/indica") {
+		t.Errorf("Expected a single race search but got %v", *calls)
+	}
+}
+
+func TestStrainQueryStopsWhenEmpty(t *testing.T) {
+	client, calls := newQueryTestClient()
+
+	strains, err := client.Query().WithFlavor("Skunk").WithEffect("Relaxed").Exclude(EffectTypeNegative, "Paranoid").Run(context.Background())
+	if err != nil || len(strains) != 0 {
+		t.Errorf("Expected no strains but got %v (error: %v)", strains, err)
+	}
+
+	if len(*calls) != 2 {
+		t.Errorf("Expected no exclusion search once nothing matched but got %v", *calls)
+	}
+}
+
+func TestStrainQueryNamePrefix(t *testing.T) {
+	client, _ := newQueryTestClient()
+
+	strains, err := client.Query().Name("og*").Run(context.Background())
+	if err != nil || len(strains) != 1 || strains[0].Name != "OG Kush" {
+		t.Errorf("Expected only OG Kush but got %v (error: %v)", strains, err)
+	}
+
+	if strains, _ := client.Query().Name("og").Run(context.Background()); len(strains) != 2 {
+		t.Errorf("Expected both strains containing og but got %v", strains)
+	}
+}
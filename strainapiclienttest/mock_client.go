@@ -0,0 +1,234 @@
+// Package strainapiclienttest provides test doubles for code that uses
+// a strainapiclient.Client.
+package strainapiclienttest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+// The method names used with MockClient.SetError and MockClient.Calls.
+const (
+	ListAllEffects                 string = "ListAllEffects"
+	ListAllFlavors                        = "ListAllFlavors"
+	ListAllStrains                        = "ListAllStrains"
+	SearchStrainsByName                   = "SearchStrainsByName"
+	SearchStrainsByRace                   = "SearchStrainsByRace"
+	SearchStrainsByFlavor                 = "SearchStrainsByFlavor"
+	SearchStrainsByEffectName             = "SearchStrainsByEffectName"
+	GetStrainDescriptionByStrainID        = "GetStrainDescriptionByStrainID"
+	GetStrainFlavorsByStrainID            = "GetStrainFlavorsByStrainID"
+	GetStrainEffectsByStrainID            = "GetStrainEffectsByStrainID"
+)
+
+// MockClient is a strainapiclient.Client that returns canned responses,
+// counts calls per method, and returns injected errors.
+//
+// Set the exported fields to the responses each method should return.
+// Lookups by ID, name, race, flavor, or effect that have no canned
+// response return an error wrapping strainapiclient.ErrNotFound.
+type MockClient struct {
+	Effects []strainapiclient.Effect
+	Flavors []strainapiclient.Flavor
+	Strains strainapiclient.ListAllStrainsResult
+
+	NameResults   map[string]strainapiclient.SearchStrainsByNameResults
+	RaceResults   map[strainapiclient.Race]strainapiclient.SearchStrainsByRaceResults
+	FlavorResults map[strainapiclient.Flavor]strainapiclient.SearchStrainsByFlavorResults
+	EffectResults map[string]strainapiclient.SearchStrainsByEffectNameResults
+
+	Descriptions  map[int]string
+	StrainFlavors map[int][]strainapiclient.Flavor
+	StrainEffects map[int]strainapiclient.EffectsByEffectType
+
+	mutex          sync.Mutex
+	calls          map[string]int
+	errors         map[string]error
+	requestHandler strainapiclient.HandleResourceRequestFunc
+}
+
+// NewMockClient creates a new MockClient with no canned responses.
+func NewMockClient() *MockClient {
+	return &MockClient{calls: make(map[string]int), errors: make(map[string]error)}
+}
+
+// SetError makes every call to method return err (nil clears it).
+func (m *MockClient) SetError(method string, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.errors[method] = err
+}
+
+// Calls returns how many times method has been called.
+func (m *MockClient) Calls(method string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.calls[method]
+}
+
+// ResetCalls sets every call counter back to zero.
+func (m *MockClient) ResetCalls() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls = make(map[string]int)
+}
+
+// call records a call to method and returns its injected error, if any.
+func (m *MockClient) call(method string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls[method]++
+	return m.errors[method]
+}
+
+func notFound(method string, key interface{}) error {
+	return fmt.Errorf("%s has no canned response for %v: %w", method, key, strainapiclient.ErrNotFound)
+}
+
+// ListAllEffects implements strainapiclient.Client.
+func (m *MockClient) ListAllEffects() ([]strainapiclient.Effect, error) {
+	if err := m.call(ListAllEffects); err != nil {
+		return make([]strainapiclient.Effect, 0), err
+	}
+
+	return m.Effects, nil
+}
+
+// ListAllFlavors implements strainapiclient.Client.
+func (m *MockClient) ListAllFlavors() ([]strainapiclient.Flavor, error) {
+	if err := m.call(ListAllFlavors); err != nil {
+		return make([]strainapiclient.Flavor, 0), err
+	}
+
+	return m.Flavors, nil
+}
+
+// ListAllStrains implements strainapiclient.Client.
+func (m *MockClient) ListAllStrains() (strainapiclient.ListAllStrainsResult, error) {
+	if err := m.call(ListAllStrains); err != nil {
+		return make(strainapiclient.ListAllStrainsResult), err
+	}
+
+	return m.Strains, nil
+}
+
+// SearchStrainsByName implements strainapiclient.Client.
+func (m *MockClient) SearchStrainsByName(name string) (strainapiclient.SearchStrainsByNameResults, error) {
+	if err := m.call(SearchStrainsByName); err != nil {
+		return make(strainapiclient.SearchStrainsByNameResults, 0), err
+	}
+
+	results, found := m.NameResults[name]
+	if !found {
+		return make(strainapiclient.SearchStrainsByNameResults, 0), notFound(SearchStrainsByName, name)
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByRace implements strainapiclient.Client.
+func (m *MockClient) SearchStrainsByRace(race strainapiclient.Race) (strainapiclient.SearchStrainsByRaceResults, error) {
+	if err := m.call(SearchStrainsByRace); err != nil {
+		return make(strainapiclient.SearchStrainsByRaceResults, 0), err
+	}
+
+	results, found := m.RaceResults[race]
+	if !found {
+		return make(strainapiclient.SearchStrainsByRaceResults, 0), notFound(SearchStrainsByRace, race)
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByFlavor implements strainapiclient.Client.
+func (m *MockClient) SearchStrainsByFlavor(flavor strainapiclient.Flavor) (strainapiclient.SearchStrainsByFlavorResults, error) {
+	if err := m.call(SearchStrainsByFlavor); err != nil {
+		return make(strainapiclient.SearchStrainsByFlavorResults, 0), err
+	}
+
+	results, found := m.FlavorResults[flavor]
+	if !found {
+		return make(strainapiclient.SearchStrainsByFlavorResults, 0), notFound(SearchStrainsByFlavor, flavor)
+	}
+
+	return results, nil
+}
+
+// SearchStrainsByEffectName implements strainapiclient.Client.
+func (m *MockClient) SearchStrainsByEffectName(effectName string) (strainapiclient.SearchStrainsByEffectNameResults, error) {
+	if err := m.call(SearchStrainsByEffectName); err != nil {
+		return make(strainapiclient.SearchStrainsByEffectNameResults, 0), err
+	}
+
+	results, found := m.EffectResults[effectName]
+	if !found {
+		return make(strainapiclient.SearchStrainsByEffectNameResults, 0), notFound(SearchStrainsByEffectName, effectName)
+	}
+
+	return results, nil
+}
+
+// GetStrainDescriptionByStrainID implements strainapiclient.Client.
+func (m *MockClient) GetStrainDescriptionByStrainID(id int) (string, error) {
+	if err := m.call(GetStrainDescriptionByStrainID); err != nil {
+		return "", err
+	}
+
+	description, found := m.Descriptions[id]
+	if !found {
+		return "", notFound(GetStrainDescriptionByStrainID, id)
+	}
+
+	return description, nil
+}
+
+// GetStrainFlavorsByStrainID implements strainapiclient.Client.
+func (m *MockClient) GetStrainFlavorsByStrainID(id int) ([]strainapiclient.Flavor, error) {
+	if err := m.call(GetStrainFlavorsByStrainID); err != nil {
+		return make([]strainapiclient.Flavor, 0), err
+	}
+
+	flavors, found := m.StrainFlavors[id]
+	if !found {
+		return make([]strainapiclient.Flavor, 0), notFound(GetStrainFlavorsByStrainID, id)
+	}
+
+	return flavors, nil
+}
+
+// GetStrainEffectsByStrainID implements strainapiclient.Client.
+func (m *MockClient) GetStrainEffectsByStrainID(id int) (strainapiclient.EffectsByEffectType, error) {
+	if err := m.call(GetStrainEffectsByStrainID); err != nil {
+		return make(strainapiclient.EffectsByEffectType), err
+	}
+
+	effects, found := m.StrainEffects[id]
+	if !found {
+		return make(strainapiclient.EffectsByEffectType), notFound(GetStrainEffectsByStrainID, id)
+	}
+
+	return effects, nil
+}
+
+// SetHandleResourceRequestFunc implements strainapiclient.Client.  The
+// MockClient never makes requests, so the handler is only stored.
+func (m *MockClient) SetHandleResourceRequestFunc(f strainapiclient.HandleResourceRequestFunc) strainapiclient.HandleResourceRequestFunc {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	current := m.requestHandler
+	m.requestHandler = f
+	return current
+}
+
+// Capabilities implements strainapiclient.CapabilityReporter.  The
+// MockClient only returns canned responses, so it is offline.
+func (m *MockClient) Capabilities() strainapiclient.Capabilities {
+	return strainapiclient.Capabilities{Offline: true}
+}
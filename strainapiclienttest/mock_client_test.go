@@ -0,0 +1,40 @@
+package strainapiclienttest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+func TestMockClient(t *testing.T) {
+	mock := NewMockClient()
+	mock.Flavors = []strainapiclient.Flavor{"Earthy", "Pine"}
+	mock.StrainFlavors = map[int][]strainapiclient.Flavor{1: {"Earthy"}}
+
+	var client strainapiclient.Client = mock
+
+	flavors, err := client.ListAllFlavors()
+	if err != nil || !reflect.DeepEqual(flavors, mock.Flavors) {
+		t.Errorf("Expected canned flavors %v but got %v (error: %v)", mock.Flavors, flavors, err)
+	}
+
+	if _, err := client.GetStrainFlavorsByStrainID(2); !errors.Is(err, strainapiclient.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an ID with no canned response but got %v", err)
+	}
+
+	injected := errors.New("injected")
+	mock.SetError(ListAllFlavors, injected)
+	if _, err := client.ListAllFlavors(); err != injected {
+		t.Errorf("Expected the injected error but got %v", err)
+	}
+
+	if calls := mock.Calls(ListAllFlavors); calls != 2 {
+		t.Errorf("Expected 2 calls to ListAllFlavors but got %d", calls)
+	}
+
+	if calls := mock.Calls(GetStrainFlavorsByStrainID); calls != 1 {
+		t.Errorf("Expected 1 call to GetStrainFlavorsByStrainID but got %d", calls)
+	}
+}
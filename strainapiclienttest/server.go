@@ -0,0 +1,284 @@
+package strainapiclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+// TestAPIKey is the API Key a fixture Server accepts.
+const TestAPIKey string = "test-api-key"
+
+// Fixtures is the data a fixture Server serves.
+type Fixtures struct {
+	Effects []strainapiclient.Effect             `json:"effects"`
+	Flavors []strainapiclient.Flavor             `json:"flavors"`
+	Strains strainapiclient.ListAllStrainsResult `json:"strains"`
+}
+
+// LoadFixtures reads Fixtures from JSON in the form
+// {"effects": [...], "flavors": [...], "strains": {"Name": {...}}}.
+func LoadFixtures(r io.Reader) (Fixtures, error) {
+	fixtures := Fixtures{}
+
+	if err := json.NewDecoder(r).Decode(&fixtures); err != nil {
+		return fixtures, fmt.Errorf("Problem parsing fixtures: %w", err)
+	}
+
+	for name, strain := range fixtures.Strains {
+		strain.Name = name
+		fixtures.Strains[name] = strain
+	}
+
+	return fixtures, nil
+}
+
+// DefaultFixtures returns a small, fixed dataset shaped like the real API's.
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Effects: []strainapiclient.Effect{
+			{Name: "Relaxed", Type: strainapiclient.EffectTypePositive},
+			{Name: "Happy", Type: strainapiclient.EffectTypePositive},
+			{Name: "Energetic", Type: strainapiclient.EffectTypePositive},
+			{Name: "Sleepy", Type: strainapiclient.EffectTypePositive},
+			{Name: "Dizzy", Type: strainapiclient.EffectTypeNegative},
+			{Name: "Paranoid", Type: strainapiclient.EffectTypeNegative},
+			{Name: "Stress", Type: strainapiclient.EffectTypeMedical},
+			{Name: "Insomnia", Type: strainapiclient.EffectTypeMedical},
+		},
+		Flavors: []strainapiclient.Flavor{"Earthy", "Chemical", "Pine", "Sweet", "Citrus", "Diesel"},
+		Strains: strainapiclient.ListAllStrainsResult{
+			"Afpak": {
+				Name: "Afpak", ID: 1, Race: strainapiclient.RaceHybrid,
+				Description: "Afpak, named for its direct Afghani and Pakistani landrace heritage, is a beautiful indica-dominant hybrid.",
+				Flavors:     []strainapiclient.Flavor{"Earthy", "Chemical", "Pine"},
+				Effects: map[strainapiclient.EffectType][]string{
+					strainapiclient.EffectTypePositive: {"Relaxed", "Happy", "Sleepy"},
+					strainapiclient.EffectTypeNegative: {"Dizzy"},
+					strainapiclient.EffectTypeMedical:  {"Stress", "Insomnia"},
+				},
+			},
+			"Blue Dream": {
+				Name: "Blue Dream", ID: 2, Race: strainapiclient.RaceHybrid,
+				Description: "Blue Dream is a sativa-dominant hybrid with a sweet berry aroma.",
+				Flavors:     []strainapiclient.Flavor{"Sweet", "Earthy"},
+				Effects: map[strainapiclient.EffectType][]string{
+					strainapiclient.EffectTypePositive: {"Happy", "Relaxed"},
+					strainapiclient.EffectTypeNegative: {"Paranoid"},
+					strainapiclient.EffectTypeMedical:  {"Stress"},
+				},
+			},
+			"Northern Lights": {
+				Name: "Northern Lights", ID: 3, Race: strainapiclient.RaceIndica,
+				Description: "Northern Lights is a pure indica known for its resinous buds and relaxing effects.",
+				Flavors:     []strainapiclient.Flavor{"Pine", "Sweet"},
+				Effects: map[strainapiclient.EffectType][]string{
+					strainapiclient.EffectTypePositive: {"Relaxed", "Sleepy"},
+					strainapiclient.EffectTypeNegative: {"Dizzy"},
+					strainapiclient.EffectTypeMedical:  {"Insomnia"},
+				},
+			},
+			"Sour Diesel": {
+				Name: "Sour Diesel", ID: 4, Race: strainapiclient.RaceSativa,
+				Description: "Sour Diesel is an invigorating sativa named after its pungent, diesel-like aroma.",
+				Flavors:     []strainapiclient.Flavor{"Diesel", "Citrus"},
+				Effects: map[strainapiclient.EffectType][]string{
+					strainapiclient.EffectTypePositive: {"Energetic", "Happy"},
+					strainapiclient.EffectTypeNegative: {"Paranoid"},
+					strainapiclient.EffectTypeMedical:  {"Stress"},
+				},
+			},
+		},
+	}
+}
+
+// Server is an httptest.Server emulating the Strain API routes, backed by
+// Fixtures, so the real DefaultClient can be tested without the network.
+// Requests must use TestAPIKey; others get a 401.  Unknown strain IDs get
+// a 404.
+type Server struct {
+	*httptest.Server
+	fixtures Fixtures
+}
+
+// NewServer starts a new Server serving fixtures.  Call Close when done.
+func NewServer(fixtures Fixtures) *Server {
+	server := &Server{fixtures: fixtures}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// Client returns a DefaultClient that talks to the server with TestAPIKey
+// and retries disabled; any opts are applied after those.
+func (s *Server) Client(opts ...strainapiclient.Option) *strainapiclient.DefaultClient {
+	allOpts := []strainapiclient.Option{
+		strainapiclient.WithBaseURL(s.URL),
+		strainapiclient.WithRetryPolicy(strainapiclient.NoRetryPolicy),
+	}
+
+	return strainapiclient.NewClient(TestAPIKey, append(allOpts, opts...)...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if segments[0] != TestAPIKey {
+		http.Error(w, "Invalid API Key", http.StatusUnauthorized)
+		return
+	}
+
+	route := segments[1:]
+	switch {
+	case len(route) == 0:
+		w.Write([]byte("Seems legit to me man..."))
+	case len(route) == 2 && route[0] == "searchdata" && route[1] == "effects":
+		writeJSON(w, s.fixtures.Effects)
+	case len(route) == 2 && route[0] == "searchdata" && route[1] == "flavors":
+		writeJSON(w, s.fixtures.Flavors)
+	case len(route) == 3 && route[0] == "strains" && route[1] == "search" && route[2] == "all":
+		writeJSON(w, s.listing())
+	case len(route) == 4 && route[0] == "strains" && route[1] == "search":
+		s.serveSearch(w, route[2], route[3])
+	case len(route) == 4 && route[0] == "strains" && route[1] == "data":
+		s.serveData(w, route[2], route[3])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// listedStrain is a strain as /strains/search/all lists it: keyed by name,
+// and without its description.
+type listedStrain struct {
+	ID      int                                     `json:"id"`
+	Race    strainapiclient.Race                    `json:"race"`
+	Flavors []strainapiclient.Flavor                `json:"flavors"`
+	Effects map[strainapiclient.EffectType][]string `json:"effects"`
+}
+
+// listing returns the fixture strains the way the API lists them, so
+// callers that need descriptions have to fetch them.
+func (s *Server) listing() map[string]listedStrain {
+	listing := make(map[string]listedStrain)
+	for name, strain := range s.fixtures.Strains {
+		listing[name] = listedStrain{ID: strain.ID, Race: strain.Race, Flavors: strain.Flavors, Effects: strain.Effects}
+	}
+
+	return listing
+}
+
+// sortedStrains returns the fixture strains in ID order, like the API.
+func (s *Server) sortedStrains() []strainapiclient.Strain {
+	strains := make([]strainapiclient.Strain, 0)
+	for _, strain := range s.fixtures.Strains {
+		strains = append(strains, strain)
+	}
+
+	sort.Slice(strains, func(i, j int) bool { return strains[i].ID < strains[j].ID })
+	return strains
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, searchType string, term string) {
+	switch searchType {
+	case "name":
+		results := make(strainapiclient.SearchStrainsByNameResults, 0)
+		for _, strain := range s.sortedStrains() {
+			if strings.Contains(strings.ToLower(strain.Name), strings.ToLower(term)) {
+				results = append(results, strainapiclient.SearchStrainsByNameResult{
+					Name: strain.Name, ID: strain.ID, Race: strain.Race, Description: strain.Description,
+				})
+			}
+		}
+		writeJSON(w, results)
+	case "race":
+		results := make(strainapiclient.SearchStrainsByRaceResults, 0)
+		for _, strain := range s.sortedStrains() {
+			if string(strain.Race) == term {
+				results = append(results, strainapiclient.SearchStrainsByRaceResult{Name: strain.Name, ID: strain.ID, Race: strain.Race})
+			}
+		}
+		writeJSON(w, results)
+	case "effect":
+		results := make(strainapiclient.SearchStrainsByEffectNameResults, 0)
+		for _, strain := range s.sortedStrains() {
+			for _, effectNames := range strain.Effects {
+				if containsString(effectNames, term) {
+					results = append(results, strainapiclient.SearchStrainsByEffectNameResult{
+						Name: strain.Name, ID: strain.ID, Race: strain.Race, EffectName: term,
+					})
+					break
+				}
+			}
+		}
+		writeJSON(w, results)
+	case "flavor":
+		results := make(strainapiclient.SearchStrainsByFlavorResults, 0)
+		for _, strain := range s.sortedStrains() {
+			for _, flavor := range strain.Flavors {
+				if string(flavor) == term {
+					results = append(results, strainapiclient.SearchStrainsByFlavorResult{
+						Name: strain.Name, ID: strain.ID, Race: strain.Race, Flavor: flavor,
+					})
+					break
+				}
+			}
+		}
+		writeJSON(w, results)
+	default:
+		http.Error(w, "Unknown search type", http.StatusNotFound)
+	}
+}
+
+func (s *Server) serveData(w http.ResponseWriter, dataElementName string, idString string) {
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		http.Error(w, "Invalid strain ID", http.StatusBadRequest)
+		return
+	}
+
+	var strain *strainapiclient.Strain
+	for _, candidate := range s.sortedStrains() {
+		if candidate.ID == id {
+			found := candidate
+			strain = &found
+			break
+		}
+	}
+
+	if strain == nil {
+		http.Error(w, "Strain not found", http.StatusNotFound)
+		return
+	}
+
+	switch dataElementName {
+	case "desc":
+		writeJSON(w, map[string]string{"desc": strain.Description})
+	case "flavors":
+		writeJSON(w, strain.Flavors)
+	case "effects":
+		writeJSON(w, strain.Effects)
+	default:
+		http.Error(w, "Unknown data element", http.StatusNotFound)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
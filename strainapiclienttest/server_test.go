@@ -0,0 +1,64 @@
+package strainapiclienttest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tchype/strainapiclient-go"
+)
+
+func TestServerWithDefaultClient(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+
+	client := server.Client()
+
+	if !client.CanConnect() {
+		t.Error("Expected to be able to connect to the fixture server")
+	}
+
+	strains, err := client.ListAllStrains()
+	if err != nil || len(strains) != 4 || strains["Afpak"].ID != 1 {
+		t.Errorf("Expected the 4 fixture strains but got %v (error: %v)", strains, err)
+	}
+
+	if strains["Afpak"].Description != "" {
+		t.Errorf("Expected the listing to leave out descriptions like the API but got '%s'", strains["Afpak"].Description)
+	}
+
+	byName, err := client.SearchStrainsByName("dream")
+	if err != nil || len(byName) != 1 || byName[0].Name != "Blue Dream" {
+		t.Errorf("Expected to find Blue Dream by name but got %v (error: %v)", byName, err)
+	}
+
+	byEffect, err := client.SearchStrainsByEffectName("Sleepy")
+	if err != nil || len(byEffect) != 2 || byEffect[0].Name != "Afpak" || byEffect[1].Name != "Northern Lights" {
+		t.Errorf("Expected Afpak and Northern Lights for Sleepy but got %v (error: %v)", byEffect, err)
+	}
+
+	flavors, err := client.GetStrainFlavorsByStrainID(4)
+	if err != nil || !reflect.DeepEqual(flavors, []strainapiclient.Flavor{"Diesel", "Citrus"}) {
+		t.Errorf("Expected Sour Diesel's flavors but got %v (error: %v)", flavors, err)
+	}
+
+	effects, err := client.GetStrainEffectsByStrainID(3)
+	if err != nil || len(effects[strainapiclient.EffectTypePositive]) != 2 {
+		t.Errorf("Expected Northern Lights' effects but got %v (error: %v)", effects, err)
+	}
+
+	if _, err := client.GetStrainDescriptionByStrainID(99); !errors.Is(err, strainapiclient.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown ID but got %v", err)
+	}
+}
+
+func TestServerRejectsOtherAPIKeys(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+
+	client := strainapiclient.NewClient("wrong-key", strainapiclient.WithBaseURL(server.URL))
+
+	if _, err := client.ListAllFlavors(); !errors.Is(err, strainapiclient.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized but got %v", err)
+	}
+}
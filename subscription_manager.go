@@ -0,0 +1,214 @@
+package strainapiclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StrainSubscription delivers change events for one watched strain from a
+// SubscriptionManager.
+type StrainSubscription struct {
+	id      int
+	manager *SubscriptionManager
+	events  chan StrainWatchEvent
+	done    chan struct{}
+
+	closeOnce sync.Once
+	mutex     sync.Mutex
+	closed    bool
+}
+
+// ID returns the ID of the strain the subscription watches.
+func (s *StrainSubscription) ID() int {
+	return s.id
+}
+
+// Events returns the channel events for the strain are delivered on.  It is
+// closed when the subscription is closed or the manager stops running.
+func (s *StrainSubscription) Events() <-chan StrainWatchEvent {
+	return s.events
+}
+
+// Close stops the subscription and closes its Events channel.
+func (s *StrainSubscription) Close() {
+	s.manager.unsubscribe(s)
+	s.close()
+}
+
+// close closes the subscription once, however many times it is called by
+// Close and Run.  done is closed first so a blocked deliver gives up the
+// mutex.
+func (s *StrainSubscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.closed = true
+		close(s.events)
+	})
+}
+
+// deliver sends event unless the subscription is closed first.
+func (s *StrainSubscription) deliver(ctx context.Context, event StrainWatchEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.events <- event:
+	case <-s.done:
+	case <-ctx.Done():
+	}
+}
+
+// SubscriptionManager watches many strains with a single refresh loop.
+// Every interval it fetches the description, flavors, and effects of each
+// watched strain once with GetStrainsByIDs, however many subscriptions the
+// strain has, and fans the field-level changes out to its subscribers.  Like
+// WatchStrain, it doesn't compare races, which the per-strain endpoints
+// don't return.  Each refresh makes three calls per watched strain.
+//
+// Subscribers must keep reading their Events channel; a refresh waits for
+// each event to be delivered.
+type SubscriptionManager struct {
+	client   Client
+	interval time.Duration
+
+	mutex         sync.Mutex
+	subscriptions map[int][]*StrainSubscription
+	previous      map[int]Strain
+}
+
+// NewSubscriptionManager creates a new SubscriptionManager that refreshes
+// the strains watched through client every interval once Run is called; an
+// interval that isn't positive is DefaultPollInterval.
+func NewSubscriptionManager(client Client, interval time.Duration) *SubscriptionManager {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &SubscriptionManager{
+		client:        client,
+		interval:      interval,
+		subscriptions: make(map[int][]*StrainSubscription),
+		previous:      make(map[int]Strain),
+	}
+}
+
+// Subscribe starts watching the strain with id.  The first refresh that
+// includes the strain sets its baseline; later refreshes emit an event
+// when one of its fields changes.
+func (m *SubscriptionManager) Subscribe(id int) *StrainSubscription {
+	subscription := &StrainSubscription{
+		id:      id,
+		manager: m,
+		events:  make(chan StrainWatchEvent, 1),
+		done:    make(chan struct{}),
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.subscriptions[id] = append(m.subscriptions[id], subscription)
+
+	return subscription
+}
+
+func (m *SubscriptionManager) unsubscribe(subscription *StrainSubscription) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	remaining := make([]*StrainSubscription, 0)
+	for _, existing := range m.subscriptions[subscription.id] {
+		if existing != subscription {
+			remaining = append(remaining, existing)
+		}
+	}
+
+	if len(remaining) == 0 {
+		delete(m.subscriptions, subscription.id)
+		delete(m.previous, subscription.id)
+		return
+	}
+
+	m.subscriptions[subscription.id] = remaining
+}
+
+// Run refreshes the watched strains every interval until ctx is done, then
+// closes every subscription.
+func (m *SubscriptionManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.refresh(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			m.mutex.Lock()
+			subscriptions := m.subscriptions
+			m.subscriptions = make(map[int][]*StrainSubscription)
+			m.mutex.Unlock()
+
+			for _, idSubscriptions := range subscriptions {
+				for _, subscription := range idSubscriptions {
+					subscription.close()
+				}
+			}
+			return
+		}
+	}
+}
+
+// refresh fetches each watched strain once and delivers its changes to the
+// strain's subscribers.
+func (m *SubscriptionManager) refresh(ctx context.Context) {
+	m.mutex.Lock()
+	ids := make([]int, 0)
+	for id := range m.subscriptions {
+		ids = append(ids, id)
+	}
+	m.mutex.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	strains, errs := GetStrainsByIDs(m.client, ids)
+	now := time.Now()
+
+	events := make(map[*StrainSubscription]StrainWatchEvent)
+
+	m.mutex.Lock()
+	for id, subscriptions := range m.subscriptions {
+		event := StrainWatchEvent{ID: id, Time: now}
+
+		if err, failed := errs[id]; failed {
+			event.Err = fmt.Errorf("Problem refreshing strain with ID %d: %w", id, err)
+		} else if current, found := strains[id]; found {
+			if previous, seen := m.previous[id]; seen {
+				event.Changes = diffStrainFields(previous, current)
+			}
+			m.previous[id] = current
+		}
+
+		if event.Err != nil || len(event.Changes) > 0 {
+			for _, subscription := range subscriptions {
+				events[subscription] = event
+			}
+		}
+	}
+	m.mutex.Unlock()
+
+	for subscription, event := range events {
+		subscription.deliver(ctx, event)
+	}
+}
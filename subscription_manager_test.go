@@ -0,0 +1,124 @@
+package strainapiclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSubscriptionTestClient serves a listing without descriptions, like the
+// API's, and per-strain data for IDs 1 to 3.  The description of strain 1
+// changes after its first fetch.  calls counts the requests for each path.
+func newSubscriptionTestClient(calls *sync.Map) *DefaultClient {
+	var descriptionCalls int32
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		count, _ := calls.LoadOrStore(path, new(int32))
+		atomic.AddInt32(count.(*int32), 1)
+
+		switch {
+		case strings.HasSuffix(path, "/strains/search/all"):
+			return []byte(`{
+				"Afpak": {"id": 1, "race": "hybrid", "flavors": ["Earthy"], "effects": {"positive": ["Relaxed"]}},
+				"Blue Dream": {"id": 2, "race": "hybrid", "flavors": ["Sweet"], "effects": {"positive": ["Happy"]}},
+				"Chemdawg": {"id": 3, "race": "hybrid", "flavors": ["Diesel"], "effects": {"positive": ["Euphoric"]}}
+			}`), nil
+		case strings.HasSuffix(path, "/strains/data/desc/1"):
+			if atomic.AddInt32(&descriptionCalls, 1) > 1 {
+				return []byte(`{"desc": "Updated"}`), nil
+			}
+			return []byte(`{"desc": "Original"}`), nil
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte(`{"desc": "Unchanged"}`), nil
+		case strings.Contains(path, "/strains/data/flavors/"):
+			return []byte(`["Earthy"]`), nil
+		}
+		return []byte(`{"positive": ["Relaxed"]}`), nil
+	})
+
+	return client
+}
+
+func TestSubscriptionManagerRefreshesWatchedStrains(t *testing.T) {
+	var calls sync.Map
+	manager := NewSubscriptionManager(newSubscriptionTestClient(&calls), 10*time.Millisecond)
+	afpak := manager.Subscribe(1)
+	afpakAgain := manager.Subscribe(1)
+	blueDream := manager.Subscribe(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go manager.Run(ctx)
+
+	for _, subscription := range []*StrainSubscription{afpak, afpakAgain} {
+		event := <-subscription.Events()
+		if event.Err != nil || len(event.Changes) != 1 || event.Changes[0].Field != StrainFieldDescription ||
+			event.Changes[0].Old != "Original" || event.Changes[0].New != "Updated" {
+			t.Errorf("Expected a description change to Updated but got %+v", event)
+		}
+	}
+
+	blueDream.Close()
+	if _, open := <-blueDream.Events(); open {
+		t.Error("Expected no events for Blue Dream, which didn't change, and its channel to be closed")
+	}
+
+	cancel()
+	for range afpak.Events() {
+	}
+
+	calls.Range(func(path, count interface{}) bool {
+		if strings.HasSuffix(path.(string), "/strains/search/all") || strings.HasSuffix(path.(string), "/3") {
+			t.Errorf("Expected only the watched strains to be fetched but got %d calls to %s", atomic.LoadInt32(count.(*int32)), path)
+		}
+		return true
+	})
+
+}
+
+func TestSubscriptionCloseRacesRunShutdown(t *testing.T) {
+	client := NewSnapshotClient(newFakeSnapshot())
+
+	for i := 0; i < 20; i++ {
+		manager := NewSubscriptionManager(client, time.Millisecond)
+		subscriptions := make([]*StrainSubscription, 0)
+		for id := 1; id <= 5; id++ {
+			subscriptions = append(subscriptions, manager.Subscribe(id))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			manager.Run(ctx)
+			close(stopped)
+		}()
+
+		var wg sync.WaitGroup
+		for _, subscription := range subscriptions {
+			wg.Add(1)
+			go func(subscription *StrainSubscription) {
+				defer wg.Done()
+				subscription.Close()
+				subscription.Close()
+			}(subscription)
+		}
+		cancel()
+
+		wg.Wait()
+		<-stopped
+	}
+}
+
+func TestSubscriptionManagerDefaultsInterval(t *testing.T) {
+	manager := NewSubscriptionManager(NewSnapshotClient(newFakeSnapshot()), -time.Second)
+	if manager.interval != DefaultPollInterval {
+		t.Errorf("Expected the default interval %s but got %s", DefaultPollInterval, manager.interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	manager.Run(ctx)
+}
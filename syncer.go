@@ -0,0 +1,218 @@
+package strainapiclient
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Syncer keeps a copy of the entire dataset in memory and refreshes it in
+// the background, so long-running services always have warm, reasonably
+// fresh data.  Each refresh takes a new Snapshot, reusing the descriptions
+// of most known strains, and swaps it in atomically; readers never see a
+// partially refreshed dataset.  A refresh whose data
+// hashes the same as the current data keeps the current Client.  When a
+// refresh fails, the previous data is kept and the error is available from
+// LastError.  Strains that disappear upstream are kept as Tombstones, and
+// changes to strains are kept in their history (see
+// FileBackedClient.DeletedStrains and GetStrainHistory).
+type Syncer struct {
+	client   Client
+	interval time.Duration
+	current  atomic.Value
+
+	syncMutex            sync.Mutex
+	descriptionRefreshes int
+	descriptionCursor    int
+
+	mutex        sync.Mutex
+	lastSyncTime time.Time
+	lastError    error
+	watchers     map[*syncWatcher]bool
+	statsLog     *DatasetStatsLog
+}
+
+// SyncDescriptionRefreshes is the number of known strains whose
+// descriptions each sync fetches again, taking turns in ID order.
+const SyncDescriptionRefreshes int = 100
+
+// NewSyncer creates a new Syncer that refreshes the dataset through client
+// every interval once Run is called; an interval that isn't positive is
+// DefaultPollInterval.  Until the first successful sync, the Syncer serves
+// an empty dataset.
+//
+// The API doesn't list descriptions, so each one is a separate call.  The
+// first sync makes three listing calls plus one description call per
+// strain (about 2,000).  Later syncs make the three listing calls plus one
+// description call for each new strain and for SyncDescriptionRefreshes
+// known strains, so every description is fetched again about once every
+// 20 syncs.
+func NewSyncer(client Client, interval time.Duration) *Syncer {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	syncer := &Syncer{client: client, interval: interval, descriptionRefreshes: SyncDescriptionRefreshes, watchers: make(map[*syncWatcher]bool)}
+	syncer.current.Store(NewSnapshotClient(Snapshot{Effects: make([]Effect, 0), Flavors: make([]Flavor, 0), Strains: make(ListAllStrainsResult)}))
+
+	return syncer
+}
+
+// Run syncs immediately and then every interval until ctx is done.  Run it
+// in its own goroutine.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sync()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync refreshes the dataset now and returns the error, if any, that is
+// also recorded as LastError.  Syncs run one at a time.  With a stats log
+// set, Sync also returns any error recording the dataset's stats.
+func (s *Syncer) Sync() error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	previousClient := s.Client()
+	previous, _ := previousClient.data()
+	first := s.LastSyncTime().IsZero()
+
+	snapshot, err := takeSnapshot(s.client, func(strains ListAllStrainsResult) (ListAllStrainsResult, error) {
+		return s.hydrate(previous, strains)
+	})
+	changed := err == nil && snapshot.Hash() != previous.Hash()
+
+	var diff SnapshotDiff
+	if changed {
+		next := NewSnapshotClient(snapshot)
+		if !first {
+			diff = Diff(previous, snapshot)
+			next.carryHistory(previousClient, diff)
+		}
+		s.current.Store(next)
+	}
+
+	s.mutex.Lock()
+	s.lastError = err
+	if err == nil {
+		s.lastSyncTime = time.Now()
+	}
+	syncTime, statsLog := s.lastSyncTime, s.statsLog
+	watchers := make([]*syncWatcher, 0)
+	for watcher := range s.watchers {
+		watchers = append(watchers, watcher)
+	}
+	s.mutex.Unlock()
+
+	if changed && !first && len(watchers) > 0 {
+		s.notify(watchers, diff)
+	}
+
+	if err == nil && statsLog != nil {
+		err = statsLog.Append(ComputeDatasetStats(s.Snapshot(), syncTime))
+	}
+
+	return err
+}
+
+// hydrate fills in the descriptions of strains, as listed for a sync.
+// Strains that aren't in previous are hydrated, as are the next
+// descriptionRefreshes of the ones that are, in ID order after where the
+// last sync stopped.  The rest keep their previous descriptions.
+func (s *Syncer) hydrate(previous Snapshot, strains ListAllStrainsResult) (ListAllStrainsResult, error) {
+	previousByID := make(map[int]Strain)
+	for _, strain := range previous.Strains {
+		previousByID[strain.ID] = strain
+	}
+
+	known := make([]string, 0)
+	pending := make(ListAllStrainsResult)
+	for name, strain := range strains {
+		if _, found := previousByID[strain.ID]; found {
+			known = append(known, name)
+		} else {
+			pending[name] = strain
+		}
+	}
+	sort.Slice(known, func(i, j int) bool { return strains[known[i]].ID < strains[known[j]].ID })
+
+	cursor := s.descriptionCursor
+	start := sort.Search(len(known), func(i int) bool { return strains[known[i]].ID > cursor })
+	for i := 0; i < len(known) && i < s.descriptionRefreshes; i++ {
+		name := known[(start+i)%len(known)]
+		pending[name] = strains[name]
+		cursor = strains[name].ID
+	}
+
+	hydrated, err := hydrateStrains(context.Background(), s.client, pending, DefaultBatchConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(ListAllStrainsResult)
+	for name, strain := range strains {
+		if fetched, found := hydrated[name]; found {
+			strain = fetched
+		} else if strain.Description == "" {
+			strain.Description = previousByID[strain.ID].Description
+		}
+		result[name] = strain
+	}
+	s.descriptionCursor = cursor
+
+	return result, nil
+}
+
+// Client returns a Client serving the most recently synced dataset.  It
+// keeps serving that dataset after later syncs; call Client again for the
+// latest.
+func (s *Syncer) Client() *FileBackedClient {
+	return s.current.Load().(*FileBackedClient)
+}
+
+// Snapshot returns the most recently synced dataset.
+func (s *Syncer) Snapshot() Snapshot {
+	snapshot, _ := s.Client().data()
+	return snapshot
+}
+
+// SetStatsLog sets the DatasetStatsLog the stats of the dataset are
+// appended to after each successful sync, or nil for none, and returns the
+// previous one.
+func (s *Syncer) SetStatsLog(log *DatasetStatsLog) *DatasetStatsLog {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current := s.statsLog
+	s.statsLog = log
+	return current
+}
+
+// LastSyncTime returns when the last successful sync finished, or the zero
+// time if none has.
+func (s *Syncer) LastSyncTime() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastSyncTime
+}
+
+// LastError returns the error from the most recent sync, or nil if it
+// succeeded.
+func (s *Syncer) LastError() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastError
+}
@@ -0,0 +1,175 @@
+package strainapiclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncerSync(t *testing.T) {
+	expected := newFakeSnapshot()
+	syncer := NewSyncer(NewSnapshotClient(expected), time.Hour)
+
+	if len(syncer.Snapshot().Strains) != 0 || !syncer.LastSyncTime().IsZero() {
+		t.Error("Expected an empty dataset before the first sync")
+	}
+
+	if err := syncer.Sync(); err != nil {
+		t.Errorf("Expected no error syncing but got: %s", err)
+	}
+
+	if len(syncer.Snapshot().Strains) != len(expected.Strains) {
+		t.Errorf("Expected %d strains but got %d", len(expected.Strains), len(syncer.Snapshot().Strains))
+	}
+
+	if syncer.LastSyncTime().IsZero() || syncer.LastError() != nil {
+		t.Errorf("Expected a successful sync but got time %v and error %v", syncer.LastSyncTime(), syncer.LastError())
+	}
+
+	if strains, _ := syncer.Client().ListAllStrains(); len(strains) != len(expected.Strains) {
+		t.Errorf("Expected the client to serve %d strains but got %d", len(expected.Strains), len(strains))
+	}
+}
+
+func TestSyncerKeepsDataOnError(t *testing.T) {
+	var failing int32
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if atomic.LoadInt32(&failing) == 1 {
+			return make([]byte, 0), &APIError{StatusCode: 500}
+		}
+		if strings.Contains(path, "/strains/search/all") {
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"]}}"), nil
+		}
+		if strings.Contains(path, "/strains/data/desc/") || strings.Contains(path, "/strains/data/effects/") {
+			return []byte("{}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	syncer := NewSyncer(client, time.Hour)
+	syncer.Sync()
+	count := len(syncer.Snapshot().Strains)
+	syncTime := syncer.LastSyncTime()
+
+	atomic.StoreInt32(&failing, 1)
+	if err := syncer.Sync(); !errors.Is(err, ErrServerError) {
+		t.Errorf("Expected ErrServerError but got %v", err)
+	}
+
+	if !errors.Is(syncer.LastError(), ErrServerError) {
+		t.Errorf("Expected LastError to be ErrServerError but got %v", syncer.LastError())
+	}
+
+	if len(syncer.Snapshot().Strains) != count || count == 0 {
+		t.Errorf("Expected the previous %d strains to be kept but got %d", count, len(syncer.Snapshot().Strains))
+	}
+
+	if !syncer.LastSyncTime().Equal(syncTime) {
+		t.Errorf("Expected LastSyncTime to stay %v but got %v", syncTime, syncer.LastSyncTime())
+	}
+}
+
+func TestSyncerRefetchesDescriptionsInTurn(t *testing.T) {
+	var listed int32 = 3
+	descriptionCalls := make(chan string, 10)
+	client := NewClient("test-key", WithRetryPolicy(NoRetryPolicy))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if strings.Contains(path, "/strains/search/all") {
+			listing := "{\"Afpak\": {\"id\": 1, \"flavors\": [], \"effects\": {}}, \"Blue Dream\": {\"id\": 2, \"flavors\": [], \"effects\": {}}, \"Chemdawg\": {\"id\": 3, \"flavors\": [], \"effects\": {}}"
+			if atomic.LoadInt32(&listed) == 4 {
+				listing += ", \"Durban\": {\"id\": 4, \"flavors\": [], \"effects\": {}}"
+			}
+			return []byte(listing + "}"), nil
+		}
+		if strings.Contains(path, "/strains/data/desc/") {
+			descriptionCalls <- path[strings.LastIndex(path, "/")+1:]
+			return []byte("{\"desc\": \"Described\"}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	syncer := NewSyncer(client, time.Hour)
+	syncer.descriptionRefreshes = 1
+
+	expectCalls := func(expected ...string) {
+		t.Helper()
+		actual := make([]string, 0)
+		for len(descriptionCalls) > 0 {
+			actual = append(actual, <-descriptionCalls)
+		}
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected descriptions %v to be fetched but got %v", expected, actual)
+		}
+	}
+
+	syncer.Sync()
+	expectCalls("1", "2", "3")
+
+	syncer.Sync()
+	expectCalls("1")
+
+	atomic.StoreInt32(&listed, 4)
+	syncer.Sync()
+	expectCalls("2", "4")
+
+	for name, strain := range syncer.Snapshot().Strains {
+		if strain.Description != "Described" {
+			t.Errorf("Expected %s to keep its description but got '%s'", name, strain.Description)
+		}
+	}
+}
+
+func TestSyncerRun(t *testing.T) {
+	syncer := NewSyncer(NewSnapshotClient(newFakeSnapshot()), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		syncer.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(25 * time.Millisecond)
+	first := syncer.LastSyncTime()
+	time.Sleep(25 * time.Millisecond)
+	cancel()
+	<-done
+
+	if first.IsZero() || !syncer.LastSyncTime().After(first) {
+		t.Errorf("Expected repeated syncs but got %v then %v", first, syncer.LastSyncTime())
+	}
+}
+
+func TestSyncerKeepsClientWhenUnchanged(t *testing.T) {
+	syncer := NewSyncer(NewSnapshotClient(newFakeSnapshot()), time.Hour)
+
+	syncer.Sync()
+	client := syncer.Client()
+	syncer.Sync()
+
+	if syncer.Client() != client {
+		t.Error("Expected an unchanged dataset to keep the same client")
+	}
+}
+
+func TestSyncerDefaultsInterval(t *testing.T) {
+	syncer := NewSyncer(NewSnapshotClient(newFakeSnapshot()), 0)
+	if syncer.interval != DefaultPollInterval {
+		t.Errorf("Expected the default interval %s but got %s", DefaultPollInterval, syncer.interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	syncer.Run(ctx)
+
+	if syncer.LastSyncTime().IsZero() {
+		t.Error("Expected Run to sync before stopping")
+	}
+}
@@ -0,0 +1,113 @@
+package strainapiclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StrainChangeKind is how a strain changed between two syncs.
+type StrainChangeKind string
+
+// The valid values of StrainChangeKind
+const (
+	StrainChangeAdded    StrainChangeKind = "added"
+	StrainChangeRemoved                   = "removed"
+	StrainChangeModified                  = "modified"
+)
+
+// StrainChangeEvent is emitted by Syncer.Watch for each strain that was
+// added, removed, or modified by a sync.  Strain is the strain as it was
+// before it was removed and as it is now otherwise; Changes is only set for
+// modified strains.
+type StrainChangeEvent struct {
+	Kind    StrainChangeKind
+	Strain  Strain
+	Changes []StrainFieldChange
+	Time    time.Time
+}
+
+// syncWatcher is one Watch call's channel.
+type syncWatcher struct {
+	ctx    context.Context
+	events chan StrainChangeEvent
+
+	mutex  sync.Mutex
+	closed bool
+}
+
+// deliver sends event unless the watcher's context is done first.
+func (w *syncWatcher) deliver(event StrainChangeEvent) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.events <- event:
+	case <-w.ctx.Done():
+	}
+}
+
+func (w *syncWatcher) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	close(w.events)
+}
+
+// Watch returns a channel of the strains added, removed, or modified by each
+// sync that changes the dataset, so consumers can reindex or notify without
+// polling themselves.  The first successful sync sets the baseline and
+// emits nothing.  The channel is closed once ctx is done.
+//
+// Watchers must keep reading the channel; a sync waits for each event to be
+// delivered.
+func (s *Syncer) Watch(ctx context.Context) <-chan StrainChangeEvent {
+	watcher := &syncWatcher{ctx: ctx, events: make(chan StrainChangeEvent, 1)}
+
+	s.mutex.Lock()
+	s.watchers[watcher] = true
+	s.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mutex.Lock()
+		delete(s.watchers, watcher)
+		s.mutex.Unlock()
+
+		watcher.close()
+	}()
+
+	return watcher.events
+}
+
+// notify delivers the events for diff to watchers.
+func (s *Syncer) notify(watchers []*syncWatcher, diff SnapshotDiff) {
+	now := time.Now()
+	events := make([]StrainChangeEvent, 0)
+
+	for _, strain := range diff.Added {
+		events = append(events, StrainChangeEvent{Kind: StrainChangeAdded, Strain: strain, Time: now})
+	}
+
+	for _, strain := range diff.Removed {
+		events = append(events, StrainChangeEvent{Kind: StrainChangeRemoved, Strain: strain, Time: now})
+	}
+
+	current := s.Client()
+	for _, change := range diff.Changed {
+		strain, _ := current.strain(change.ID)
+		events = append(events, StrainChangeEvent{Kind: StrainChangeModified, Strain: strain, Changes: change.Changes, Time: now})
+	}
+
+	for _, watcher := range watchers {
+		for _, event := range events {
+			watcher.deliver(event)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package strainapiclient
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncerWatch(t *testing.T) {
+	var strainsJSON atomic.Value
+	strainsJSON.Store("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\"}, \"Blue\": {\"id\": 2, \"race\": \"indica\"}}")
+
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		if strings.Contains(path, "/strains/search/all") {
+			return []byte(strainsJSON.Load().(string)), nil
+		}
+		if strings.Contains(path, "/strains/data/desc/") || strings.Contains(path, "/strains/data/effects/") {
+			return []byte("{}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	syncer := NewSyncer(client, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	events := syncer.Watch(ctx)
+
+	syncer.Sync()
+	strainsJSON.Store("{\"Afpak\": {\"id\": 1, \"race\": \"sativa\"}, \"Cherry\": {\"id\": 3, \"race\": \"hybrid\"}}")
+	go syncer.Sync()
+
+	kinds := make(map[StrainChangeKind]int)
+	for i := 0; i < 3; i++ {
+		event := <-events
+		kinds[event.Kind] = event.Strain.ID
+
+		if event.Kind == StrainChangeModified && (len(event.Changes) != 1 || event.Changes[0].Field != StrainFieldRace) {
+			t.Errorf("Expected a race change but got %v", event.Changes)
+		}
+	}
+
+	if kinds[StrainChangeAdded] != 3 || kinds[StrainChangeRemoved] != 2 || kinds[StrainChangeModified] != 1 {
+		t.Errorf("Expected strain 3 added, 2 removed, and 1 modified but got %v", kinds)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestSyncerWatchFirstSyncIsBaseline(t *testing.T) {
+	syncer := NewSyncer(NewSnapshotClient(newFakeSnapshot()), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	events := syncer.Watch(ctx)
+
+	syncer.Sync()
+	cancel()
+
+	for event := range events {
+		t.Errorf("Expected no events for the first sync but got %v", event)
+	}
+}
@@ -0,0 +1,108 @@
+package strainapiclient
+
+import (
+	"sort"
+	"time"
+)
+
+// Tombstone is a strain deleted from a FileBackedClient and when it was
+// deleted.
+type Tombstone struct {
+	Strain    Strain    `json:"strain"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// DeleteStrain soft-deletes the strains with ids: they are no longer
+// served, but are kept as Tombstones (see DeletedStrains and
+// IncludeDeleted) so their data and anything keyed by their IDs stay
+// meaningful.  Putting a deleted strain again restores it.  It returns the
+// number of strains deleted.
+func (c *FileBackedClient) DeleteStrain(ids ...int) int {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+
+	deleted := make([]Strain, 0)
+	for _, id := range ids {
+		if strain, found := c.byID[id]; found {
+			deleted = append(deleted, strain)
+		}
+	}
+
+	c.tombstone(time.Now(), deleted...)
+	return len(deleted)
+}
+
+// DeletedStrains returns the Tombstones of the deleted strains, in ID
+// order.
+func (c *FileBackedClient) DeletedStrains() []Tombstone {
+	c.dataMutex.RLock()
+	defer c.dataMutex.RUnlock()
+
+	tombstones := make([]Tombstone, 0)
+	for _, tombstone := range c.tombstones {
+		tombstones = append(tombstones, tombstone)
+	}
+
+	sort.Slice(tombstones, func(i, j int) bool { return tombstones[i].Strain.ID < tombstones[j].Strain.ID })
+	return tombstones
+}
+
+// IncludeDeleted returns a new FileBackedClient serving the client's
+// current strains plus its deleted ones, for audits and for following
+// links to strains that have since been removed.  Later changes to either
+// client don't affect the other.
+func (c *FileBackedClient) IncludeDeleted() *FileBackedClient {
+	c.dataMutex.RLock()
+	defer c.dataMutex.RUnlock()
+
+	snapshot := c.snapshot
+	snapshot.Strains = make(ListAllStrainsResult)
+	for name, strain := range c.snapshot.Strains {
+		snapshot.Strains[name] = strain
+	}
+	for _, tombstone := range c.tombstones {
+		if _, found := snapshot.Strains[tombstone.Strain.Name]; !found {
+			snapshot.Strains[tombstone.Strain.Name] = tombstone.Strain
+		}
+	}
+
+	return NewSnapshotClient(snapshot)
+}
+
+// tombstone removes strains from the data the client serves and records
+// them as deleted at deletedAt; the caller holds dataMutex.
+func (c *FileBackedClient) tombstone(deletedAt time.Time, strains ...Strain) {
+	if len(strains) == 0 {
+		return
+	}
+
+	removed := make(map[int]bool)
+	for _, strain := range strains {
+		removed[strain.ID] = true
+	}
+
+	byName := make(ListAllStrainsResult)
+	for name, existing := range c.snapshot.Strains {
+		if !removed[existing.ID] {
+			byName[name] = existing
+		}
+	}
+
+	byID := make(map[int]Strain)
+	for id, existing := range c.byID {
+		if !removed[id] {
+			byID[id] = existing
+		}
+	}
+
+	if c.tombstones == nil {
+		c.tombstones = make(map[int]Tombstone)
+	}
+	for _, strain := range strains {
+		c.tombstones[strain.ID] = Tombstone{Strain: strain, DeletedAt: deletedAt}
+		c.addRevision(strain.ID, StrainRevision{Previous: strain, Deleted: true, Time: deletedAt})
+	}
+
+	c.snapshot.Strains = byName
+	c.byID = byID
+}
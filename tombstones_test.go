@@ -0,0 +1,69 @@
+package strainapiclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeleteStrain(t *testing.T) {
+	client := NewSnapshotClient(newFakeSnapshot())
+	total := len(client.snapshot.Strains)
+	strains, _ := client.ListAllStrains()
+
+	var target Strain
+	for _, strain := range strains {
+		target = strain
+		break
+	}
+
+	if deleted := client.DeleteStrain(target.ID, 999999); deleted != 1 {
+		t.Errorf("Expected 1 strain deleted but got %d", deleted)
+	}
+
+	if _, err := client.GetStrainFlavorsByStrainID(target.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the deleted strain to be excluded but got %v", err)
+	}
+
+	tombstones := client.DeletedStrains()
+	if len(tombstones) != 1 || tombstones[0].Strain.ID != target.ID || tombstones[0].DeletedAt.IsZero() {
+		t.Errorf("Expected a tombstone for strain %d but got %v", target.ID, tombstones)
+	}
+
+	if all, _ := client.IncludeDeleted().ListAllStrains(); len(all) != total {
+		t.Errorf("Expected %d strains including deleted ones but got %d", total, len(all))
+	}
+
+	client.PutStrain(target)
+	if len(client.DeletedStrains()) != 0 || len(client.snapshot.Strains) != total {
+		t.Error("Expected putting the strain again to restore it")
+	}
+}
+
+func TestSyncerTombstonesRemovedStrains(t *testing.T) {
+	upstream := NewSnapshotClient(newFakeSnapshot())
+	syncer := NewSyncer(upstream, time.Hour)
+	syncer.Sync()
+
+	strains, _ := upstream.ListAllStrains()
+	var removed Strain
+	for _, strain := range strains {
+		removed = strain
+		break
+	}
+
+	upstream.DeleteStrain(removed.ID)
+	syncer.Sync()
+
+	tombstones := syncer.Client().DeletedStrains()
+	if len(tombstones) != 1 || tombstones[0].Strain.ID != removed.ID {
+		t.Fatalf("Expected a tombstone for strain %d after the sync but got %v", removed.ID, tombstones)
+	}
+
+	upstream.PutStrain(Strain{Name: "Brand New", ID: 999999, Race: RaceHybrid})
+	syncer.Sync()
+
+	if tombstones := syncer.Client().DeletedStrains(); len(tombstones) != 1 {
+		t.Errorf("Expected the tombstone to survive the next sync but got %v", tombstones)
+	}
+}
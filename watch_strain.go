@@ -0,0 +1,151 @@
+package strainapiclient
+
+import (
+	"context"
+	"time"
+)
+
+// StrainField names a field of a Strain that can change.
+type StrainField string
+
+// The valid values of StrainField
+const (
+	StrainFieldDescription     StrainField = "description"
+	StrainFieldRace                        = "race"
+	StrainFieldFlavors                     = "flavors"
+	StrainFieldPositiveEffects             = "effects.positive"
+	StrainFieldNegativeEffects             = "effects.negative"
+	StrainFieldMedicalEffects              = "effects.medical"
+)
+
+// StrainFieldChange describes how one field of a strain changed.  For
+// Description and Race, Old and New hold the previous and current values;
+// for flavors and effects, Added and Removed hold the names that changed.
+type StrainFieldChange struct {
+	Field   StrainField `json:"field"`
+	Old     string      `json:"old,omitempty"`
+	New     string      `json:"new,omitempty"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+// DefaultPollInterval is the interval used by WatchStrain, Syncer, and
+// SubscriptionManager when given one that isn't positive.
+const DefaultPollInterval time.Duration = time.Minute
+
+// StrainWatchEvent is emitted by WatchStrain when a poll finds changes or
+// fails.  Err is set (and Changes empty) when the poll failed.
+type StrainWatchEvent struct {
+	ID      int
+	Time    time.Time
+	Changes []StrainFieldChange
+	Err     error
+}
+
+// WatchStrain polls the description, flavors, and effects of the strain with
+// id every interval and emits an event whenever a field changes, so callers
+// can alert when a tracked strain is updated.  The first poll sets the
+// baseline and only emits an event if it fails.  The channel is closed once
+// ctx is done.  An interval that isn't positive is DefaultPollInterval.
+func WatchStrain(ctx context.Context, client Client, id int, interval time.Duration) <-chan StrainWatchEvent {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	events := make(chan StrainWatchEvent)
+
+	go func() {
+		defer close(events)
+
+		var previous *Strain
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			current, err := fetchWatchedStrain(client, id)
+
+			event := StrainWatchEvent{ID: id, Time: time.Now(), Err: err}
+			if err == nil {
+				if previous != nil {
+					event.Changes = diffStrainFields(*previous, current)
+				}
+				previous = &current
+			}
+
+			if event.Err != nil || len(event.Changes) > 0 {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// fetchWatchedStrain fetches the per-strain data WatchStrain compares.
+func fetchWatchedStrain(client Client, id int) (Strain, error) {
+	description, err := client.GetStrainDescriptionByStrainID(id)
+	if err != nil {
+		return Strain{}, err
+	}
+
+	flavors, err := client.GetStrainFlavorsByStrainID(id)
+	if err != nil {
+		return Strain{}, err
+	}
+
+	effects, err := client.GetStrainEffectsByStrainID(id)
+	if err != nil {
+		return Strain{}, err
+	}
+
+	return Strain{ID: id, Description: description, Flavors: flavors, Effects: effectNamesByType(effects)}, nil
+}
+
+// diffStrainFields returns the field-level changes between two versions of
+// a strain.  Flavors and effects are compared as sets so reordering isn't
+// reported as a change.
+func diffStrainFields(old Strain, new Strain) []StrainFieldChange {
+	changes := make([]StrainFieldChange, 0)
+
+	if old.Description != new.Description {
+		changes = append(changes, StrainFieldChange{Field: StrainFieldDescription, Old: old.Description, New: new.Description})
+	}
+
+	if old.Race != new.Race {
+		changes = append(changes, StrainFieldChange{Field: StrainFieldRace, Old: string(old.Race), New: string(new.Race)})
+	}
+
+	if _, removed, added := diffFlavors(old.Flavors, new.Flavors); len(added) > 0 || len(removed) > 0 {
+		change := StrainFieldChange{Field: StrainFieldFlavors, Added: make([]string, 0), Removed: make([]string, 0)}
+		for _, flavor := range added {
+			change.Added = append(change.Added, string(flavor))
+		}
+		for _, flavor := range removed {
+			change.Removed = append(change.Removed, string(flavor))
+		}
+		changes = append(changes, change)
+	}
+
+	effectFields := map[EffectType]StrainField{
+		EffectTypePositive: StrainFieldPositiveEffects,
+		EffectTypeNegative: StrainFieldNegativeEffects,
+		EffectTypeMedical:  StrainFieldMedicalEffects,
+	}
+	for _, effectType := range []EffectType{EffectTypePositive, EffectTypeNegative, EffectTypeMedical} {
+		if _, removed, added := diffStrings(old.Effects[effectType], new.Effects[effectType]); len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, StrainFieldChange{Field: effectFields[effectType], Added: added, Removed: removed})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,86 @@
+package strainapiclient
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchStrainEmitsFieldChanges(t *testing.T) {
+	var polls int32
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/data/desc/"):
+			if atomic.AddInt32(&polls, 1) > 1 {
+				return []byte("{\"desc\": \"Updated description\"}"), nil
+			}
+			return []byte("{\"desc\": \"Original description\"}"), nil
+		case strings.Contains(path, "/strains/data/flavors/"):
+			return []byte("[\"Citrus\", \"Pine\"]"), nil
+		}
+		return []byte("{\"positive\": [\"Happy\"], \"negative\": [], \"medical\": []}"), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event, ok := <-WatchStrain(ctx, client, 1, 10*time.Millisecond)
+	if !ok {
+		t.Error("Expected a change event before the channel closed")
+		return
+	}
+
+	if event.Err != nil || len(event.Changes) != 1 {
+		t.Errorf("Expected 1 change and no error but got %v", event)
+		return
+	}
+
+	change := event.Changes[0]
+	if change.Field != StrainFieldDescription || change.Old != "Original description" || change.New != "Updated description" {
+		t.Errorf("Expected a description change but got %+v", change)
+	}
+}
+
+func TestWatchStrainDefaultsInterval(t *testing.T) {
+	client := NewDefaultClient("test-key")
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		return make([]byte, 0), &APIError{StatusCode: 404}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := WatchStrain(ctx, client, 1, 0)
+
+	if event := <-events; event.Err == nil {
+		t.Errorf("Expected the first poll's error but got %v", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestDiffStrainFieldsComparesSets(t *testing.T) {
+	old := Strain{
+		Flavors: []Flavor{"Citrus", "Pine"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Happy", "Relaxed"}},
+	}
+	new := Strain{
+		Flavors: []Flavor{"Pine", "Citrus"},
+		Effects: map[EffectType][]string{EffectTypePositive: {"Relaxed", "Sleepy"}},
+	}
+
+	changes := diffStrainFields(old, new)
+	if len(changes) != 1 {
+		t.Errorf("Expected only the positive effects to change but got %+v", changes)
+		return
+	}
+
+	change := changes[0]
+	if change.Field != StrainFieldPositiveEffects || len(change.Added) != 1 || change.Added[0] != "Sleepy" ||
+		len(change.Removed) != 1 || change.Removed[0] != "Happy" {
+		t.Errorf("Expected Sleepy added and Happy removed but got %+v", change)
+	}
+}
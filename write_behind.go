@@ -0,0 +1,97 @@
+package strainapiclient
+
+import "sync"
+
+// maxWriteBehindQueue is how many writes can wait to be applied to the
+// store; further writes are dropped until the queue drains.
+const maxWriteBehindQueue int = 1000
+
+// writeBehind applies results fetched by a DefaultClient to a local store
+// in the background, one write at a time.
+type writeBehind struct {
+	store *FileBackedClient
+
+	mutex   sync.Mutex
+	queue   []func()
+	running bool
+}
+
+// WithWriteBehind makes the client save the strains it fetches to store in
+// the background, so normal traffic gradually builds an offline dataset
+// (for example, for a CompositeClient or Snapshot) without explicit syncs.
+// ListAllStrains adds every strain, merged into any stored strain so
+// descriptions fetched earlier are kept (see MergeStrain); per-strain
+// descriptions, flavors, and effects update strains the store already has.  Calls never
+// wait for the store, and writes are dropped if too many are waiting.
+func WithWriteBehind(store *FileBackedClient) Option {
+	return func(c *DefaultClient) {
+		c.writeBehind = &writeBehind{store: store}
+	}
+}
+
+// enqueue queues write to run in the background.  It is a no-op on a nil
+// writeBehind, so callers don't need to check whether it's configured.
+func (w *writeBehind) enqueue(write func()) {
+	if w == nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.queue) >= maxWriteBehindQueue {
+		return
+	}
+	w.queue = append(w.queue, write)
+
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+}
+
+// run applies queued writes until the queue is empty.
+func (w *writeBehind) run() {
+	for {
+		w.mutex.Lock()
+		if len(w.queue) == 0 {
+			w.running = false
+			w.mutex.Unlock()
+			return
+		}
+
+		write := w.queue[0]
+		w.queue = w.queue[1:]
+		w.mutex.Unlock()
+
+		write()
+	}
+}
+
+// putStrains queues strains to be merged into the store.  The strains are
+// copied first, since the caller keeps using the map.
+func (w *writeBehind) putStrains(strains ListAllStrainsResult) {
+	if w == nil {
+		return
+	}
+
+	batch := make([]Strain, 0)
+	for _, strain := range strains {
+		batch = append(batch, strain)
+	}
+
+	w.enqueue(func() {
+		w.store.MergeStrain(batch...)
+	})
+}
+
+// updateStrain queues update to be applied to the stored strain with id.
+func (w *writeBehind) updateStrain(id int, update func(strain *Strain)) {
+	if w == nil {
+		return
+	}
+
+	w.enqueue(func() {
+		w.store.updateStrain(id, update)
+	})
+}
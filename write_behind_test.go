@@ -0,0 +1,79 @@
+package strainapiclient
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitForStore polls store until check passes or a second has gone by.
+func waitForStore(store *FileBackedClient, check func(strains ListAllStrainsResult) bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if strains, _ := store.ListAllStrains(); check(strains) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestWithWriteBehind(t *testing.T) {
+	store := NewSnapshotClient(Snapshot{Strains: make(ListAllStrainsResult)})
+
+	client := NewClient("test-key", WithWriteBehind(store))
+	client.SetHandleResourceRequestFunc(func(path string) ([]byte, error) {
+		switch {
+		case strings.Contains(path, "/strains/search/all"):
+			return []byte("{\"Afpak\": {\"id\": 1, \"race\": \"hybrid\", \"flavors\": [\"Earthy\"]}}"), nil
+		case strings.Contains(path, "/strains/data/desc/"):
+			return []byte("{\"desc\": \"Fetched live\"}"), nil
+		case strings.Contains(path, "/strains/data/effects/"):
+			return []byte("{\"positive\": [\"Relaxed\"]}"), nil
+		}
+		return []byte("[]"), nil
+	})
+
+	// Strains the store doesn't have yet aren't created from per-strain data
+	client.GetStrainDescriptionByStrainID(1)
+	client.ListAllStrains()
+	if !waitForStore(store, func(strains ListAllStrainsResult) bool { return strains["Afpak"].ID == 1 }) {
+		t.Fatal("Expected the listed strain to be written to the store")
+	}
+
+	client.GetStrainDescriptionByStrainID(1)
+	client.GetStrainEffectsByStrainID(1)
+	written := waitForStore(store, func(strains ListAllStrainsResult) bool {
+		afpak := strains["Afpak"]
+		return afpak.Description == "Fetched live" && len(afpak.Effects[EffectTypePositive]) == 1
+	})
+	if !written {
+		strains, _ := store.ListAllStrains()
+		t.Errorf("Expected the description and effects to be written to the store but got %v", strains["Afpak"])
+	}
+
+	// Listing again merges into the stored strain rather than erasing the
+	// description and effects; the marker update runs after the merge
+	client.ListAllStrains()
+	client.writeBehind.updateStrain(1, func(strain *Strain) { strain.Race = RaceSativa })
+	waitForStore(store, func(strains ListAllStrainsResult) bool { return strains["Afpak"].Race == RaceSativa })
+
+	strains, _ := store.ListAllStrains()
+	if afpak := strains["Afpak"]; afpak.Description != "Fetched live" || len(afpak.Effects[EffectTypePositive]) != 1 {
+		t.Errorf("Expected listing again to keep the description and effects but got %v", afpak)
+	}
+
+	history, _ := store.GetStrainHistory(1)
+	for _, revision := range history {
+		for _, change := range revision.Changes {
+			if change.Field == StrainFieldDescription && change.New == "" {
+				t.Errorf("Expected no revision erasing the description but got %+v", revision)
+			}
+		}
+	}
+}
+
+func TestWithoutWriteBehind(t *testing.T) {
+	var unconfigured *writeBehind
+	unconfigured.putStrains(ListAllStrainsResult{"Afpak": {ID: 1}})
+	unconfigured.updateStrain(1, func(strain *Strain) {})
+}